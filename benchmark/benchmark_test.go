@@ -5,11 +5,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"runtime"
+	"sync"
 	"testing"
 	"time"
 
 	"uptime-go/internal/models"
 	"uptime-go/internal/monitor"
+	"uptime-go/internal/net"
 	"uptime-go/internal/net/database"
 
 	"github.com/rs/zerolog/log"
@@ -181,3 +183,137 @@ func BenchmarkMonitor100Sites(b *testing.B) {
 func BenchmarkMonitor500Sites(b *testing.B) {
 	benchmarkMonitor(b, 500)
 }
+
+// checkOnly performs the network check of a monitor without touching the database
+func checkOnly(cfg *models.Monitor) {
+	nc := &net.NetworkConfig{
+		URL:     cfg.URL,
+		Timeout: cfg.ResponseTimeThreshold,
+	}
+	_, _ = nc.CheckWebsite()
+}
+
+// startGoroutinePerMonitor reproduces the previous scheduling model:
+// one goroutine with its own ticker for every monitor.
+func startGoroutinePerMonitor(configs []*models.Monitor, check func(*models.Monitor), stop <-chan struct{}, wg *sync.WaitGroup) {
+	for _, cfg := range configs {
+		wg.Add(1)
+		go func(cfg *models.Monitor) {
+			defer wg.Done()
+
+			ticker := time.NewTicker(cfg.Interval)
+			defer ticker.Stop()
+
+			check(cfg)
+			for {
+				select {
+				case <-ticker.C:
+					check(cfg)
+				case <-stop:
+					return
+				}
+			}
+		}(cfg)
+	}
+}
+
+// runScheduling monitors configs for the given duration with either the
+// goroutine-per-monitor model or the central scheduler, and returns the peak
+// goroutine count sampled meanwhile.
+func runScheduling(configs []*models.Monitor, useScheduler bool, duration time.Duration) int {
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	var scheduler *monitor.Scheduler
+
+	if useScheduler {
+		scheduler = monitor.NewScheduler(monitor.SchedulerConfig{
+			MaxConcurrentChecks: monitor.DefaultMaxConcurrentChecks,
+			StartJitter:         time.Second,
+		}, checkOnly)
+		for _, cfg := range configs {
+			scheduler.Add(cfg)
+		}
+		scheduler.Start()
+	} else {
+		startGoroutinePerMonitor(configs, checkOnly, stop, &wg)
+	}
+
+	// Sample the goroutine count while monitoring
+	peakGoroutines := 0
+	deadline := time.Now().Add(duration)
+	for time.Now().Before(deadline) {
+		if n := runtime.NumGoroutine(); n > peakGoroutines {
+			peakGoroutines = n
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if useScheduler {
+		scheduler.Stop()
+	} else {
+		close(stop)
+		wg.Wait()
+	}
+
+	return peakGoroutines
+}
+
+// benchmarkScheduling compares goroutine count and allocations of the
+// goroutine-per-monitor model against the central scheduler. Every
+// iteration monitors the websites for a few seconds, the reported metrics
+// are the peak goroutine count and the allocations per iteration.
+func benchmarkScheduling(b *testing.B, websiteCount int, useScheduler bool) {
+	server := createTestServer(200, 20*time.Millisecond)
+	defer server.Close()
+
+	configs := createTestConfigs(websiteCount, server)
+	monitoringDuration := 3 * time.Second
+
+	runtime.GC()
+	baseGoroutines := runtime.NumGoroutine()
+	beforeStats := getMemStats()
+	peakGoroutines := 0
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if n := runScheduling(configs, useScheduler, monitoringDuration); n > peakGoroutines {
+			peakGoroutines = n
+		}
+	}
+
+	b.StopTimer()
+
+	afterStats := getMemStats()
+
+	b.ReportMetric(float64(peakGoroutines-baseGoroutines), "goroutines")
+	b.ReportMetric(float64(afterStats.TotalAlloc-beforeStats.TotalAlloc)/float64(b.N), "alloc-bytes/op")
+
+	mode := "goroutine per monitor"
+	if useScheduler {
+		mode = "central scheduler"
+	}
+	b.Logf("%s with %d websites, %d runs of %v:", mode, websiteCount, b.N, monitoringDuration)
+	b.Logf("Peak goroutines: %d (baseline %d)", peakGoroutines, baseGoroutines)
+	printMemStats(b, beforeStats, afterStats)
+}
+
+// BenchmarkGoroutinePerMonitor500Sites measures the previous scheduling model with 500 websites
+func BenchmarkGoroutinePerMonitor500Sites(b *testing.B) {
+	benchmarkScheduling(b, 500, false)
+}
+
+// BenchmarkScheduler500Sites measures the central scheduler with 500 websites
+func BenchmarkScheduler500Sites(b *testing.B) {
+	benchmarkScheduling(b, 500, true)
+}
+
+// BenchmarkGoroutinePerMonitor2000Sites measures the previous scheduling model with 2000 websites
+func BenchmarkGoroutinePerMonitor2000Sites(b *testing.B) {
+	benchmarkScheduling(b, 2000, false)
+}
+
+// BenchmarkScheduler2000Sites measures the central scheduler with 2000 websites
+func BenchmarkScheduler2000Sites(b *testing.B) {
+	benchmarkScheduling(b, 2000, true)
+}
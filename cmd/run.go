@@ -1,15 +1,15 @@
 package cmd
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"uptime-go/internal/api"
 	"uptime-go/internal/configuration"
-	"uptime-go/internal/helper"
 	"uptime-go/internal/monitor"
-	"uptime-go/internal/models"
 	"uptime-go/internal/net"
 	"uptime-go/internal/net/database"
 
@@ -42,15 +42,6 @@ Example:
 			Str("master_url", configuration.Config.Agent.MasterHost).
 			Msg("configuration")
 
-		var urls []string
-		configByURL := make(map[string]models.Monitor, len(configs))
-
-		for _, r := range configs {
-			r.ID = helper.GenerateRandomID()
-			urls = append(urls, r.URL)
-			configByURL[r.URL] = *r
-		}
-
 		// Initialize database
 		db, err := database.New(databasePath)
 		if err != nil {
@@ -59,42 +50,10 @@ Example:
 		}
 
 		// Merge config
-		db.UpsertRecord(configs, "url", &[]string{
-			"url",
-			"enabled",
-			"response_time_threshold",
-			"interval",
-			"certificate_monitoring",
-			"certificate_expired_before",
-			"follow_redirects",
-			"ip_type",
-			"max_retries",
-			"retry_interval",
-			"dns_timeout",
-			"dial_timeout",
-			"tls_handshake_timeout",
-			"response_header_timeout",
-		})
-		db.DB.Where("url IN ?", urls).Find(&configs)
-		for _, cfg := range configs {
-			src, ok := configByURL[cfg.URL]
-			if !ok {
-				continue
-			}
-			// Ensure runtime uses config values while keeping DB state fields.
-			cfg.Enabled = src.Enabled
-			cfg.Interval = src.Interval
-			cfg.ResponseTimeThreshold = src.ResponseTimeThreshold
-			cfg.CertificateMonitoring = src.CertificateMonitoring
-			cfg.CertificateExpiredBefore = src.CertificateExpiredBefore
-			cfg.FollowRedirects = src.FollowRedirects
-			cfg.IPType = src.IPType
-			cfg.MaxRetries = src.MaxRetries
-			cfg.RetryInterval = src.RetryInterval
-			cfg.DNSTimeout = src.DNSTimeout
-			cfg.DialTimeout = src.DialTimeout
-			cfg.TLSHandshakeTimeout = src.TLSHandshakeTimeout
-			cfg.ResponseHeaderTimeout = src.ResponseHeaderTimeout
+		configs, err = db.MergeMonitors(configs)
+		if err != nil {
+			log.Error().Err(err).Msg("Error merging configuration")
+			return err
 		}
 
 		// Initialize and start monitor
@@ -108,6 +67,54 @@ Example:
 			uptimeMonitor.Start()
 		}()
 
+		// Apply configuration changes without restarting. applied is the
+		// digest of the configuration file the monitor runs with, the file
+		// watcher skips events that leave it unchanged such as the write
+		// made by the config API, which reloads on its own.
+		var (
+			reloadMutex sync.Mutex
+			applied     = configDigest(configPath)
+		)
+		apply := func(onlyChanged bool) error {
+			reloadMutex.Lock()
+			defer reloadMutex.Unlock()
+
+			digest := configDigest(configPath)
+			if onlyChanged {
+				if digest == applied {
+					return nil
+				}
+				log.Info().Str("config", configPath).Msg("configuration file changed, reloading...")
+			}
+
+			set, err := configuration.LoadMonitors(configPath)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			monitors, err := db.MergeMonitors(set.Monitors)
+			if err != nil {
+				return err
+			}
+
+			uptimeMonitor.SetMaintenance(set.Maintenance)
+			uptimeMonitor.Reload(monitors)
+			applied = digest
+			return nil
+		}
+		reload := func() error {
+			return apply(false)
+		}
+
+		stopWatcher, err := configuration.Watch(configPath, func() {
+			if err := apply(true); err != nil {
+				log.Error().Err(err).Msg("failed to reload configuration")
+			}
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to watch configuration file, changes require SIGHUP or restart")
+		}
+
 		go func() {
 			log.Debug().Msg("fetching ip address...")
 			ip, err := net.GetIPAddress()
@@ -127,6 +134,7 @@ Example:
 				Bind:       apiBind,
 				Port:       apiPort,
 				ConfigPath: configPath,
+				Reload:     reload,
 			}, db)
 
 			go func() {
@@ -142,10 +150,28 @@ Example:
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 
-		// Wait for shutdown signal
-		<-sigChan
+		reloadChan := make(chan os.Signal, 1)
+		signal.Notify(reloadChan, syscall.SIGHUP)
+
+		// Wait for shutdown signal, reloading on SIGHUP
+	wait:
+		for {
+			select {
+			case <-reloadChan:
+				log.Info().Msg("SIGHUP received, reloading configuration...")
+				if err := reload(); err != nil {
+					log.Error().Err(err).Msg("failed to reload configuration")
+				}
+			case <-sigChan:
+				break wait
+			}
+		}
 		log.Info().Msg("Shutdown signal received, shutting down...")
 
+		if stopWatcher != nil {
+			stopWatcher()
+		}
+
 		uptimeMonitor.Shutdown()
 
 		if apiServer != nil {
@@ -156,6 +182,13 @@ Example:
 	},
 }
 
+// configDigest returns the checksum of the configuration file. A missing or
+// unreadable file hashes as empty, loading it reports the actual error.
+func configDigest(configPath string) [sha256.Size]byte {
+	content, _ := os.ReadFile(configPath)
+	return sha256.Sum256(content)
+}
+
 func init() {
 	rootCmd.AddCommand(runCmd)
 
@@ -25,7 +25,17 @@ func (s *Server) UpdateConfigHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Configuration updated successfully. Please restart the application to apply changes."})
+	if s.reload == nil {
+		c.JSON(http.StatusOK, gin.H{"message": "Configuration updated successfully. Please restart the application to apply changes."})
+		return
+	}
+
+	if err := s.reload(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Configuration updated but failed to apply", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Configuration updated and applied successfully."})
 }
 
 func (s *Server) GetMonitoringReport(c *gin.Context) {
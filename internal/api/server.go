@@ -16,12 +16,16 @@ type Server struct {
 	router     *gin.Engine
 	server     *http.Server
 	configPath string
+	reload     func() error
 }
 
 type ServerConfig struct {
 	Bind       string
 	Port       string
 	ConfigPath string
+
+	// Reload applies the configuration file to the running monitor
+	Reload func() error
 }
 
 func NewServer(cfg ServerConfig, db *database.Database) *Server {
@@ -33,6 +37,7 @@ func NewServer(cfg ServerConfig, db *database.Database) *Server {
 		db:         db,
 		router:     router,
 		configPath: cfg.ConfigPath,
+		reload:     cfg.Reload,
 		server: &http.Server{
 			Addr:         fmt.Sprintf("%s:%s", cfg.Bind, cfg.Port),
 			Handler:      router.Handler(),
@@ -0,0 +1,176 @@
+package configuration
+
+import (
+	"strings"
+	"testing"
+	"uptime-go/internal/helper"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readYAML parses a configuration snippet the way readMonitorConfig does
+func readYAML(t *testing.T, content string) *viper.Viper {
+	t.Helper()
+
+	v := viper.New()
+	v.SetConfigType("yml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(content)))
+	return v
+}
+
+const testChannels = `
+notifications:
+  channels:
+    - name: chat
+      type: Slack
+    - type: email
+    - name: chat
+      type: discord
+`
+
+func TestLoadNotificationsChannels(t *testing.T) {
+	notifications, err := loadNotifications(readYAML(t, testChannels))
+	require.NoError(t, err)
+
+	// The duplicate name is dropped, unnamed channels are named after their type
+	require.Len(t, notifications.Channels, 2)
+	assert.Equal(t, "chat", notifications.Channels[0].Name)
+	assert.Equal(t, "slack", notifications.Channels[0].Type)
+	assert.Equal(t, "email", notifications.Channels[1].Name)
+}
+
+func TestLoadNotificationsRoutes(t *testing.T) {
+	tests := []struct {
+		name   string
+		routes string
+		expect []RouteConfig
+	}{
+		{
+			name:   "no routes",
+			routes: ``,
+			expect: []RouteConfig{},
+		},
+		{
+			name: "unnamed route",
+			routes: `
+    - channels: [chat]`,
+			expect: []RouteConfig{{Name: "route-1", Channels: []string{"chat"}}},
+		},
+		{
+			name: "unknown channel is pruned",
+			routes: `
+    - name: ops
+      channels: [chat, pager, " email "]`,
+			expect: []RouteConfig{{Name: "ops", Channels: []string{"chat", "email"}}},
+		},
+		{
+			name: "route without known channel is dropped",
+			routes: `
+    - name: ops
+      channels: [pager]
+    - name: fallback
+      channels: [email]`,
+			expect: []RouteConfig{{Name: "fallback", Channels: []string{"email"}}},
+		},
+		{
+			name: "min_severity is normalized",
+			routes: `
+    - name: ops
+      min_severity: " high "
+      channels: [chat]`,
+			expect: []RouteConfig{{Name: "ops", MinSeverity: "HIGH", Channels: []string{"chat"}}},
+		},
+		{
+			name: "invalid min_severity drops the route",
+			routes: `
+    - name: ops
+      min_severity: urgent
+      channels: [chat]
+    - name: fallback
+      channels: [email]`,
+			expect: []RouteConfig{{Name: "fallback", Channels: []string{"email"}}},
+		},
+		{
+			name: "continue is kept",
+			routes: `
+    - name: ops
+      continue: true
+      channels: [chat]
+    - name: fallback
+      channels: [email]`,
+			expect: []RouteConfig{
+				{Name: "ops", Continue: true, Channels: []string{"chat"}},
+				{Name: "fallback", Channels: []string{"email"}},
+			},
+		},
+		{
+			name: "conditions are normalized",
+			routes: `
+    - name: ops
+      urls: ["https://*.example.com/*"]
+      tags: [" prod ", "", prod, db]
+      incident_types: [" Timeout "]
+      channels: [chat]`,
+			expect: []RouteConfig{{
+				Name:          "ops",
+				URLs:          []string{"https://*.example.com/*"},
+				Tags:          []string{"prod", "db"},
+				IncidentTypes: []string{"timeout"},
+				Channels:      []string{"chat"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := testChannels
+			if tt.routes != "" {
+				content += "  routes:" + tt.routes + "\n"
+			}
+
+			notifications, err := loadNotifications(readYAML(t, content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, notifications.Routes)
+		})
+	}
+}
+
+func TestLoadNotificationsRouteURLs(t *testing.T) {
+	notifications, err := loadNotifications(readYAML(t, testChannels+`
+  routes:
+    - name: api
+      urls: ["https://*.example.com/*", "*:5432"]
+      channels: [chat]
+`))
+	require.NoError(t, err)
+	require.Len(t, notifications.Routes, 1)
+
+	tests := []struct {
+		url    string
+		expect bool
+	}{
+		{url: "https://api.example.com/health", expect: true},
+		{url: "db.example.com:5432", expect: true},
+		{url: "https://example.com/health", expect: false},
+		{url: "http://api.example.com/health", expect: false},
+		{url: "db.example.com:5433", expect: false},
+	}
+
+	for _, tt := range tests {
+		matched := false
+		for _, pattern := range notifications.Routes[0].URLs {
+			matched = matched || helper.MatchGlob(pattern, tt.url)
+		}
+		assert.Equal(t, tt.expect, matched, tt.url)
+	}
+}
+
+func TestLoadNotificationsInvalid(t *testing.T) {
+	_, err := loadNotifications(readYAML(t, `
+notifications:
+  routes: invalid
+`))
+	assert.Error(t, err)
+}
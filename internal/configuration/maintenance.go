@@ -0,0 +1,135 @@
+package configuration
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"uptime-go/internal/models"
+
+	"github.com/rs/zerolog/log"
+)
+
+// MaintenanceConfig configures a maintenance window, either one-off with
+// start and end, or recurring with weekdays, from and to.
+type MaintenanceConfig struct {
+	Name     string   `mapstructure:"name" yaml:"name,omitempty" json:"name,omitempty"`
+	URLs     []string `mapstructure:"urls" yaml:"urls,omitempty" json:"urls,omitempty"` // glob patterns
+	Tags     []string `mapstructure:"tags" yaml:"tags,omitempty" json:"tags,omitempty"`
+	Timezone string   `mapstructure:"timezone" yaml:"timezone,omitempty" json:"timezone,omitempty"` // default: local time
+
+	// One-off window, e.g. 2025-06-01 22:00
+	Start string `mapstructure:"start" yaml:"start,omitempty" json:"start,omitempty"`
+	End   string `mapstructure:"end" yaml:"end,omitempty" json:"end,omitempty"`
+
+	// Recurring window, e.g. weekdays [tue, thu] from 22:00 to 02:00
+	Weekdays []string `mapstructure:"weekdays" yaml:"weekdays,omitempty" json:"weekdays,omitempty"`
+	From     string   `mapstructure:"from" yaml:"from,omitempty" json:"from,omitempty"`
+	To       string   `mapstructure:"to" yaml:"to,omitempty" json:"to,omitempty"`
+}
+
+var maintenanceTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// parseMaintenance converts the maintenance configuration, skipping the
+// invalid windows.
+func parseMaintenance(raw []MaintenanceConfig) []models.MaintenanceWindow {
+	var windows []models.MaintenanceWindow
+
+	for i, cfg := range raw {
+		if cfg.Name == "" {
+			cfg.Name = fmt.Sprintf("maintenance-%d", i+1)
+		}
+
+		window, err := parseMaintenanceWindow(cfg)
+		if err != nil {
+			log.Warn().Err(err).Msgf("ignoring maintenance window %q", cfg.Name)
+			continue
+		}
+		windows = append(windows, window)
+	}
+
+	return windows
+}
+
+func parseMaintenanceWindow(cfg MaintenanceConfig) (models.MaintenanceWindow, error) {
+	window := models.MaintenanceWindow{
+		Name:     cfg.Name,
+		URLs:     cfg.URLs,
+		Tags:     normalizeTags(cfg.Tags),
+		Location: time.Local,
+	}
+
+	if cfg.Timezone != "" {
+		location, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return window, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+		}
+		window.Location = location
+	}
+
+	if cfg.Start != "" || cfg.End != "" {
+		start, err := parseMaintenanceTime(cfg.Start, window.Location)
+		if err != nil {
+			return window, err
+		}
+		end, err := parseMaintenanceTime(cfg.End, window.Location)
+		if err != nil {
+			return window, err
+		}
+		if !end.After(start) {
+			return window, fmt.Errorf("end %q is not after start %q", cfg.End, cfg.Start)
+		}
+
+		window.Start, window.End = start, end
+		return window, nil
+	}
+
+	if cfg.From == "" || cfg.To == "" {
+		return window, fmt.Errorf("either start and end or from and to are required")
+	}
+
+	var err error
+	if window.From, err = parseTimeOfDay(cfg.From); err != nil {
+		return window, err
+	}
+	if window.To, err = parseTimeOfDay(cfg.To); err != nil {
+		return window, err
+	}
+
+	for _, name := range cfg.Weekdays {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if len(key) > 3 {
+			key = key[:3]
+		}
+		day, ok := weekdays[key]
+		if !ok {
+			return window, fmt.Errorf("invalid weekday %q", name)
+		}
+		window.Weekdays = append(window.Weekdays, day)
+	}
+
+	return window, nil
+}
+
+func parseMaintenanceTime(value string, location *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range maintenanceTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected e.g. 2025-06-01 22:00", value)
+}
+
+// parseTimeOfDay parses "HH:MM" into an offset from midnight
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,185 @@
+package configuration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	testCases := []struct {
+		name     string
+		config   MaintenanceConfig
+		start    time.Time
+		end      time.Time
+		duration time.Duration // end - start, across DST changes
+		from     time.Duration
+		to       time.Duration
+		weekdays []time.Weekday
+		location string
+		err      string
+	}{
+		{
+			name:     "one-off in local time",
+			config:   MaintenanceConfig{Start: "2025-06-01 22:00", End: "2025-06-02 02:00"},
+			start:    time.Date(2025, 6, 1, 22, 0, 0, 0, time.Local),
+			end:      time.Date(2025, 6, 2, 2, 0, 0, 0, time.Local),
+			location: "Local",
+		},
+		{
+			name:     "one-off in a time zone",
+			config:   MaintenanceConfig{Timezone: "Europe/Berlin", Start: "2025-06-01T22:00", End: "2025-06-01 23:30:00"},
+			start:    time.Date(2025, 6, 1, 20, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 6, 1, 21, 30, 0, 0, time.UTC),
+			location: "Europe/Berlin",
+		},
+		{
+			name:     "explicit offset wins over the time zone",
+			config:   MaintenanceConfig{Timezone: "Europe/Berlin", Start: "2025-06-01T22:00:00+07:00", End: "2025-06-01T23:00:00Z"},
+			start:    time.Date(2025, 6, 1, 15, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 6, 1, 23, 0, 0, 0, time.UTC),
+			location: "Europe/Berlin",
+		},
+		{
+			// Clocks jump from 02:00 to 03:00 on 2025-03-30
+			name:     "one-off across DST start",
+			config:   MaintenanceConfig{Timezone: "Europe/Berlin", Start: "2025-03-30 01:00", End: "2025-03-30 04:00"},
+			start:    time.Date(2025, 3, 30, 1, 0, 0, 0, berlin),
+			end:      time.Date(2025, 3, 30, 4, 0, 0, 0, berlin),
+			duration: 2 * time.Hour,
+			location: "Europe/Berlin",
+		},
+		{
+			// Clocks fall back from 03:00 to 02:00 on 2025-10-26
+			name:     "one-off across DST end",
+			config:   MaintenanceConfig{Timezone: "Europe/Berlin", Start: "2025-10-26 01:00", End: "2025-10-26 04:00"},
+			start:    time.Date(2025, 10, 26, 1, 0, 0, 0, berlin),
+			end:      time.Date(2025, 10, 26, 4, 0, 0, 0, berlin),
+			duration: 4 * time.Hour,
+			location: "Europe/Berlin",
+		},
+		{
+			name:   "one-off end before start",
+			config: MaintenanceConfig{Start: "2025-06-02 02:00", End: "2025-06-01 22:00"},
+			err:    "is not after start",
+		},
+		{
+			name:   "one-off without end",
+			config: MaintenanceConfig{Start: "2025-06-01 22:00"},
+			err:    "invalid date",
+		},
+		{
+			name:   "one-off invalid date",
+			config: MaintenanceConfig{Start: "01/06/2025 22:00", End: "2025-06-02 02:00"},
+			err:    "invalid date",
+		},
+		{
+			name:     "recurring every day",
+			config:   MaintenanceConfig{From: "12:00", To: "13:00"},
+			from:     12 * time.Hour,
+			to:       13 * time.Hour,
+			location: "Local",
+		},
+		{
+			name:     "recurring on weekdays",
+			config:   MaintenanceConfig{Weekdays: []string{"mon", " Wednesday ", "FRI"}, From: "08:15", To: "09:45"},
+			from:     8*time.Hour + 15*time.Minute,
+			to:       9*time.Hour + 45*time.Minute,
+			weekdays: []time.Weekday{time.Monday, time.Wednesday, time.Friday},
+			location: "Local",
+		},
+		{
+			name:     "recurring across midnight",
+			config:   MaintenanceConfig{Timezone: "Asia/Jakarta", Weekdays: []string{"tue"}, From: "22:00", To: "02:00"},
+			from:     22 * time.Hour,
+			to:       2 * time.Hour,
+			weekdays: []time.Weekday{time.Tuesday},
+			location: "Asia/Jakarta",
+		},
+		{
+			// Recurring windows are wall clock times, DST does not shift them
+			name:     "recurring in a DST time zone",
+			config:   MaintenanceConfig{Timezone: "Europe/Berlin", Weekdays: []string{"sun"}, From: "01:00", To: "04:00"},
+			from:     1 * time.Hour,
+			to:       4 * time.Hour,
+			weekdays: []time.Weekday{time.Sunday},
+			location: "Europe/Berlin",
+		},
+		{
+			name:   "recurring invalid weekday",
+			config: MaintenanceConfig{Weekdays: []string{"someday"}, From: "22:00", To: "02:00"},
+			err:    "invalid weekday",
+		},
+		{
+			name:   "recurring invalid time",
+			config: MaintenanceConfig{From: "24:30", To: "02:00"},
+			err:    "expected HH:MM",
+		},
+		{
+			name:   "recurring without to",
+			config: MaintenanceConfig{From: "22:00"},
+			err:    "either start and end or from and to are required",
+		},
+		{
+			name:   "invalid time zone",
+			config: MaintenanceConfig{Timezone: "Mars/Olympus_Mons", From: "22:00", To: "02:00"},
+			err:    "invalid timezone",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			window, err := parseMaintenanceWindow(tc.config)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.location, window.Location.String())
+			assert.True(t, tc.start.Equal(window.Start), "start %s, got %s", tc.start, window.Start)
+			assert.True(t, tc.end.Equal(window.End), "end %s, got %s", tc.end, window.End)
+			if tc.duration != 0 {
+				assert.Equal(t, tc.duration, window.End.Sub(window.Start))
+			}
+			assert.Equal(t, tc.from, window.From)
+			assert.Equal(t, tc.to, window.To)
+			assert.Equal(t, tc.weekdays, window.Weekdays)
+			assert.Equal(t, tc.start.IsZero(), window.Recurring())
+		})
+	}
+}
+
+func TestLoadMaintenance(t *testing.T) {
+	windows, err := loadMaintenance(readYAML(t, `
+maintenance:
+  - name: deploy
+    urls: ["https://*.example.com/*"]
+    start: 2025-06-01 22:00
+    end: 2025-06-02 02:00
+  - timezone: Mars/Olympus_Mons
+    from: "22:00"
+    to: "02:00"
+  - tags: [" db ", db]
+    weekdays: [sun]
+    from: "03:00"
+    to: "04:00"
+`))
+	require.NoError(t, err)
+
+	// The invalid window is skipped, unnamed windows are named after their position
+	require.Len(t, windows, 2)
+	assert.Equal(t, "deploy", windows[0].Name)
+	assert.Equal(t, []string{"https://*.example.com/*"}, windows[0].URLs)
+	assert.False(t, windows[0].Recurring())
+	assert.Equal(t, "maintenance-3", windows[1].Name)
+	assert.Equal(t, []string{"db"}, windows[1].Tags)
+	assert.True(t, windows[1].Recurring())
+}
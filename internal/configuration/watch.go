@@ -0,0 +1,80 @@
+package configuration
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+)
+
+// watchDebounce groups the burst of events editors produce when saving a file
+const watchDebounce = 500 * time.Millisecond
+
+// Watch calls onChange whenever the configuration file is written, created
+// or replaced. The parent directory is watched so that editors saving via
+// rename are picked up as well. The returned function stops the watcher.
+func Watch(configPath string, onChange func()) (func(), error) {
+	configPath = absPath(configPath)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watcher.Add(filepath.Dir(configPath)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		var timer *time.Timer
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != configPath {
+					continue
+				}
+				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+					continue
+				}
+
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(watchDebounce, onChange)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error().Err(err).Str("path", configPath).Msg("config watcher error")
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	stop := func() {
+		close(done)
+		watcher.Close()
+		wg.Wait()
+	}
+
+	return stop, nil
+}
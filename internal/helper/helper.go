@@ -3,11 +3,13 @@ package helper
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/rs/zerolog/log"
 )
@@ -82,3 +84,162 @@ func NormalizeURL(rawURL string) string {
 	// Reconstruct the URL
 	return parsedURL.String()
 }
+
+// ParseStatusCodeRange parses an HTTP status code pattern into an inclusive range.
+// Supported forms are a single code ("401"), a range ("200-299") and a class ("3xx").
+func ParseStatusCodeRange(pattern string) (int, int, error) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+	if len(pattern) == 3 && strings.HasSuffix(pattern, "xx") {
+		class, err := strconv.Atoi(pattern[:1])
+		if err != nil || class < 1 || class > 5 {
+			return 0, 0, fmt.Errorf("invalid status code class: %q", pattern)
+		}
+		return class * 100, class*100 + 99, nil
+	}
+
+	lowRaw, highRaw, isRange := strings.Cut(pattern, "-")
+	low, err := strconv.Atoi(strings.TrimSpace(lowRaw))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid status code: %q", pattern)
+	}
+
+	high := low
+	if isRange {
+		high, err = strconv.Atoi(strings.TrimSpace(highRaw))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid status code range: %q", pattern)
+		}
+	}
+
+	if low < 100 || high > 599 || low > high {
+		return 0, 0, fmt.Errorf("status code out of range: %q", pattern)
+	}
+
+	return low, high, nil
+}
+
+// MatchStatusCode reports whether code matches any of the status code patterns.
+// Invalid patterns never match.
+func MatchStatusCode(code int, patterns []string) bool {
+	for _, pattern := range patterns {
+		low, high, err := ParseStatusCodeRange(pattern)
+		if err != nil {
+			continue
+		}
+		if code >= low && code <= high {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrDefault returns value, or defaultValue when value is blank
+func OrDefault(value, defaultValue string) string {
+	if strings.TrimSpace(value) == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// Truncate shortens s to at most limit bytes followed by "...", without
+// splitting a rune
+func Truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+	return s[:limit] + "..."
+}
+
+// MatchGlob reports whether value matches a glob pattern where '*' matches
+// any sequence of characters, '/' included, and '?' a single character.
+func MatchGlob(pattern, value string) bool {
+	p, v := []rune(pattern), []rune(value)
+	star, match := -1, 0
+
+	for i, j := 0, 0; j < len(v); {
+		switch {
+		case i < len(p) && (p[i] == '?' || p[i] == v[j]):
+			i++
+			j++
+		case i < len(p) && p[i] == '*':
+			star, match = i, j
+			i++
+		case star >= 0:
+			match++
+			i, j = star+1, match
+		default:
+			return false
+		}
+
+		if j == len(v) {
+			for i < len(p) && p[i] == '*' {
+				i++
+			}
+			return i == len(p)
+		}
+	}
+
+	return strings.Trim(pattern, "*") == ""
+}
+
+// JSONPathSegment is either an object key or an array index
+type JSONPathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// ParseJSONPath parses a restricted JSONPath such as $.db.latency_ms,
+// $.checks[0].status or $['content-type'].
+func ParseJSONPath(path string) ([]JSONPathSegment, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("json path must start with $: %q", path)
+	}
+
+	var segments []JSONPathSegment
+	rest := path[1:]
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty key in json path: %q", path)
+			}
+			segments = append(segments, JSONPathSegment{Key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated bracket in json path: %q", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				segments = append(segments, JSONPathSegment{Key: inner[1 : len(inner)-1]})
+				continue
+			}
+
+			index, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index %q in json path: %q", inner, path)
+			}
+			segments = append(segments, JSONPathSegment{Index: index, IsIndex: true})
+		default:
+			return nil, fmt.Errorf("unexpected %q in json path: %q", rest[0], path)
+		}
+	}
+
+	return segments, nil
+}
@@ -30,3 +30,87 @@ func TestParseDurationDefault(t *testing.T) {
 
 	assert.Equal(t, result, time.Duration(19)*time.Second)
 }
+
+func TestParseStatusCodeRange(t *testing.T) {
+	testCases := []struct {
+		pattern   string
+		low, high int
+		expectErr bool
+	}{
+		{pattern: "401", low: 401, high: 401},
+		{pattern: "200-299", low: 200, high: 299},
+		{pattern: " 3XX ", low: 300, high: 399},
+		{pattern: "299-200", expectErr: true},
+		{pattern: "9xx", expectErr: true},
+		{pattern: "abc", expectErr: true},
+		{pattern: "42", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			low, high, err := ParseStatusCodeRange(tc.pattern)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.low, low)
+			assert.Equal(t, tc.high, high)
+		})
+	}
+}
+
+func TestMatchStatusCode(t *testing.T) {
+	patterns := []string{"200-299", "401", "invalid"}
+
+	assert.True(t, MatchStatusCode(204, patterns))
+	assert.True(t, MatchStatusCode(401, patterns))
+	assert.False(t, MatchStatusCode(302, patterns))
+	assert.False(t, MatchStatusCode(200, nil))
+}
+
+func TestOrDefault(t *testing.T) {
+	assert.Equal(t, "value", OrDefault("value", "default"))
+	assert.Equal(t, "default", OrDefault("", "default"))
+	assert.Equal(t, "default", OrDefault("  ", "default"))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", Truncate("short", 5))
+	assert.Equal(t, "trunc...", Truncate("truncated", 5))
+	// Does not split the two byte "é"
+	assert.Equal(t, "caf...", Truncate("café", 4))
+	assert.Equal(t, "...", Truncate("é", 1))
+}
+
+func TestMatchGlob(t *testing.T) {
+	assert.True(t, MatchGlob("https://*.example.com/*", "https://api.example.com/v1/health"))
+	assert.True(t, MatchGlob("https://example.com", "https://example.com"))
+	assert.True(t, MatchGlob("*:443", "db.example.com:443"))
+	assert.True(t, MatchGlob("http?://*", "https://example.com"))
+	assert.True(t, MatchGlob("*", ""))
+	assert.False(t, MatchGlob("https://*.example.com/*", "https://example.com/"))
+	assert.False(t, MatchGlob("https://example.com", "https://example.com/path"))
+	assert.False(t, MatchGlob("?", ""))
+}
+
+func TestParseJSONPath(t *testing.T) {
+	segments, err := ParseJSONPath(`$.data[1]["db-primary"].latency_ms`)
+	assert.NoError(t, err)
+	assert.Equal(t, []JSONPathSegment{
+		{Key: "data"},
+		{Index: 1, IsIndex: true},
+		{Key: "db-primary"},
+		{Key: "latency_ms"},
+	}, segments)
+
+	segments, err = ParseJSONPath("$")
+	assert.NoError(t, err)
+	assert.Empty(t, segments)
+
+	for _, path := range []string{"", "status", "$.", "$..status", "$.items[0", "$.items[x]", "$status"} {
+		_, err := ParseJSONPath(path)
+		assert.Error(t, err, path)
+	}
+}
@@ -1,14 +1,21 @@
 package incident
 
+import "strings"
+
 type Severity string
 type Status string
 type Type string
 
 // Monitor status constants
 const (
-	StatusUP      = "UP"
-	StatusDOWN    = "DOWN"
-	StatusPENDING = "PENDING" // Waiting for retry verification
+	StatusUP          = "UP"
+	StatusDOWN        = "DOWN"
+	StatusPENDING     = "PENDING"     // Waiting for retry verification
+	StatusRECOVERING  = "RECOVERING"  // Up again, waiting for recovery confirmation
+	StatusDEGRADED    = "DEGRADED"    // Up, but slower than the degraded threshold
+	StatusPARTIAL     = "PARTIAL"     // Up, but some of the resolved addresses or an IP family are down
+	StatusFLAPPING    = "FLAPPING"    // Changing state too often, alerts are suppressed
+	StatusMAINTENANCE = "MAINTENANCE" // Inside a maintenance window, incidents are suppressed
 )
 
 const (
@@ -19,6 +26,19 @@ const (
 	CRITICAL Severity = "CRITICAL"
 )
 
+var severityLevels = map[Severity]int{INFO: 1, LOW: 2, MEDIUM: 3, HIGH: 4, CRITICAL: 5}
+
+// Level orders severities from INFO (1) to CRITICAL (5), 0 for unknown values
+func (s Severity) Level() int {
+	return severityLevels[s]
+}
+
+// ParseSeverity returns the severity named by raw, ignoring case
+func ParseSeverity(raw string) (Severity, bool) {
+	severity := Severity(strings.ToUpper(strings.TrimSpace(raw)))
+	return severity, severity.Level() > 0
+}
+
 const (
 	FalsePositive   Status = "False-Positive"
 	OnInvestigation Status = "On Investigation"
@@ -29,9 +49,28 @@ const (
 	UnexpectedStatusCode Type = "unexpected_status_code"
 	SSLExpired           Type = "certificate_expired"
 	Timeout              Type = "timeout"
+	ConnectionRefused    Type = "connection_refused"
+	DNSMismatch          Type = "dns_mismatch"
+	KeywordMismatch      Type = "keyword_mismatch"
+	JSONAssertionFailed  Type = "json_assertion_failed"
+	SlowResponse         Type = "slow_response"
+	Flapping             Type = "flapping"
+	PartialOutage        Type = "partial_outage"
+	IPv4Unreachable      Type = "ipv4_unreachable"
+	IPv6Unreachable      Type = "ipv6_unreachable"
+
+	// Certificate problems found by the TLS inspection
+	CertificateHostnameMismatch    Type = "certificate_hostname_mismatch"
+	CertificateUntrusted           Type = "certificate_untrusted"
+	CertificateWeakKey             Type = "certificate_weak_key"
+	CertificateIntermediateExpired Type = "certificate_intermediate_expired"
 )
 
 const (
 	EventWebsiteDown               string = "website_down"
 	EventWebsiteCertificateExpired string = "website_certificate_expired"
+	EventWebsiteDegraded           string = "website_degraded"
+	EventWebsiteFlapping           string = "website_flapping"
+	EventWebsiteCertificateInvalid string = "website_certificate_invalid"
+	EventWebsitePartialOutage      string = "website_partial_outage"
 )
@@ -0,0 +1,21 @@
+package models
+
+// AddressResult is the check result of one of the addresses a host resolves to
+type AddressResult struct {
+	IP           string `json:"ip"`
+	IsUp         bool   `json:"is_up"`
+	StatusCode   int    `json:"status_code"`
+	ResponseTime int64  `json:"response_time"` // in milliseconds
+	Error        string `json:"error,omitempty"`
+	Failures     int    `json:"failures,omitempty"` // consecutive failed checks
+}
+
+// FamilyResult is the check result of one IP family with ip_type both
+type FamilyResult struct {
+	Family       string `json:"family"` // ipv4 or ipv6
+	IsUp         bool   `json:"is_up"`
+	StatusCode   int    `json:"status_code"`
+	ResponseTime int64  `json:"response_time"` // in milliseconds
+	Error        string `json:"error,omitempty"`
+	Failures     int    `json:"failures,omitempty"` // consecutive failed checks
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// CertificateInfo is the result of the TLS inspection of the last check
+type CertificateInfo struct {
+	Subject            string    `json:"subject"`
+	Issuer             string    `json:"issuer"`
+	DNSNames           []string  `json:"dns_names,omitempty"`
+	IPAddresses        []string  `json:"ip_addresses,omitempty"`
+	SerialNumber       string    `json:"serial_number"`
+	KeyType            string    `json:"key_type"`
+	KeySize            int       `json:"key_size"`
+	SignatureAlgorithm string    `json:"signature_algorithm"`
+	NotBefore          time.Time `json:"not_before"`
+	NotAfter           time.Time `json:"not_after"`
+
+	// Negotiated connection parameters
+	TLSVersion  string `json:"tls_version"`
+	CipherSuite string `json:"cipher_suite"`
+
+	// Certificates sent by the server, leaf first
+	Chain []ChainCertificate `json:"chain"`
+
+	// Verification results, only set when certificate monitoring is enabled
+	Verified             bool     `json:"verified"`
+	ChainTrusted         bool     `json:"chain_trusted"` // expiry aside, see ExpiredIntermediates
+	ChainError           string   `json:"chain_error,omitempty"`
+	SelfSigned           bool     `json:"self_signed"`
+	HostnameValid        bool     `json:"hostname_valid"`
+	WeakKey              bool     `json:"weak_key"`
+	ExpiredIntermediates []string `json:"expired_intermediates,omitempty"`
+}
+
+// ChainCertificate describes a certificate of the presented chain
+type ChainCertificate struct {
+	Subject  string    `json:"subject"`
+	Issuer   string    `json:"issuer"`
+	NotAfter time.Time `json:"not_after"`
+}
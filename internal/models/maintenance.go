@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// MaintenanceWindow is a planned period during which the matching monitors
+// are still checked but no incident is opened. A window is either one-off,
+// between Start and End, or recurring on Weekdays between From and To.
+type MaintenanceWindow struct {
+	Name     string
+	URLs     []string // glob patterns, every monitor when both URLs and Tags are empty
+	Tags     []string
+	Location *time.Location
+
+	// One-off window
+	Start time.Time
+	End   time.Time
+
+	// Recurring window, From and To are offsets from midnight. A window whose
+	// To is not after From ends the next day. No weekday means every day.
+	Weekdays []time.Weekday
+	From     time.Duration
+	To       time.Duration
+}
+
+// Recurring reports whether the window repeats every week
+func (w MaintenanceWindow) Recurring() bool {
+	return w.Start.IsZero()
+}
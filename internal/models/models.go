@@ -3,6 +3,8 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"regexp"
 	"time"
 	"uptime-go/internal/helper"
 	"uptime-go/internal/incident"
@@ -12,36 +14,110 @@ import (
 )
 
 type Monitor struct {
-	ID                       string           `json:"-" gorm:"primaryKey"`
-	URL                      string           `json:"url" gorm:"unique"`
-	Enabled                  bool             `json:"-"`
-	Interval                 time.Duration    `json:"-"`
-	ResponseTimeThreshold    time.Duration    `json:"-"`
-	CertificateMonitoring    bool             `json:"-"`
-	CertificateExpiredBefore *time.Duration   `json:"-"`
-	FollowRedirects          bool             `json:"-"`
-	IPType                   string           `json:"-"`
-	IsUp                     *bool            `json:"is_up"`
-	StatusCode               *int             `json:"status_code"`
-	ResponseTime             *int64           `json:"response_time"`
-	CertificateExpiredDate   *time.Time       `json:"certificate_expired_date"`
-	LastUp                   *time.Time       `json:"last_up"`
-	LastDown                 *time.Time       `json:"last_down"`
-	CreatedAt                time.Time        `json:"-"`
-	UpdatedAt                time.Time        `json:"last_check"`
-	Histories                []MonitorHistory `json:"histories,omitempty" gorm:"foreignKey:MonitorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	Incidents                []Incident       `json:"-" gorm:"foreignKey:MonitorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ID                       string            `json:"-" gorm:"primaryKey"`
+	URL                      string            `json:"url" gorm:"unique"`
+	Type                     string            `json:"-" gorm:"default:http"`
+	Enabled                  bool              `json:"-"`
+	Interval                 time.Duration     `json:"-"`
+	ResponseTimeThreshold    time.Duration     `json:"-"`
+	DegradedThreshold        time.Duration     `json:"-"`
+	CertificateMonitoring    bool              `json:"-"`
+	CertificateExpiredBefore *time.Duration    `json:"-"`
+	FollowRedirects          bool              `json:"-"`
+	AcceptedStatusCodes      []string          `json:"-" gorm:"serializer:json"`
+	IPType                   string            `json:"-"`
+	AllAddresses             bool              `json:"-"`
+	Tags                     []string          `json:"-" gorm:"serializer:json"`
+	Method                   string            `json:"-" gorm:"default:GET"`
+	Headers                  map[string]string `json:"-" gorm:"serializer:json"`
+	Body                     string            `json:"-"`
+	Status                   string            `json:"status"`
+	Maintenance              bool              `json:"maintenance"`
+	IsUp                     *bool             `json:"is_up"`
+	StatusCode               *int              `json:"status_code"`
+	ResponseTime             *int64            `json:"response_time"`
+	CertificateExpiredDate   *time.Time        `json:"certificate_expired_date"`
+	Certificate              *CertificateInfo  `json:"certificate,omitempty" gorm:"serializer:json"`
+	Addresses                []AddressResult   `json:"addresses,omitempty" gorm:"serializer:json"` // per address results with AllAddresses
+	Families                 []FamilyResult    `json:"families,omitempty" gorm:"serializer:json"`  // per family results with ip_type both
+	LastUp                   *time.Time        `json:"last_up"`
+	LastDown                 *time.Time        `json:"last_down"`
+	CreatedAt                time.Time         `json:"-"`
+	UpdatedAt                time.Time         `json:"last_check"`
+	Histories                []MonitorHistory  `json:"histories,omitempty" gorm:"foreignKey:MonitorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Incidents                []Incident        `json:"-" gorm:"foreignKey:MonitorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 
 	// Retry configuration
 	MaxRetries    int           `json:"-" gorm:"default:3"`
 	RetryInterval time.Duration `json:"-" gorm:"default:60000000000"` // 60s in nanoseconds
 	Retries       int           `json:"-" gorm:"default:0"`
 
+	// Recovery confirmation, consecutive successful checks needed to leave DOWN
+	RecoveryThreshold int  `json:"-" gorm:"default:1"`
+	Recoveries        int  `json:"-" gorm:"default:0"`
+	Down              bool `json:"-"` // set once DOWN, cleared when the recovery is confirmed
+
+	// Reminder and escalation of open incidents
+	ReminderInterval time.Duration     `json:"-"`
+	EscalateAfter    time.Duration     `json:"-"`
+	EscalateTo       incident.Severity `json:"-"`
+
+	// Flap detection over the last FlapWindow checks, disabled when FlapThreshold is 0
+	FlapWindow    int     `json:"-"`
+	FlapThreshold float64 `json:"-"` // percentage of state changes entering FLAPPING
+	Flapping      bool    `json:"-"`
+
 	// Granular timeout configuration
 	DNSTimeout            time.Duration `json:"-" gorm:"default:5000000000"`  // 5s in nanoseconds
 	DialTimeout           time.Duration `json:"-" gorm:"default:10000000000"` // 10s in nanoseconds
 	TLSHandshakeTimeout   time.Duration `json:"-" gorm:"default:10000000000"` // 10s in nanoseconds
 	ResponseHeaderTimeout time.Duration `json:"-" gorm:"default:20000000000"` // 20s in nanoseconds
+
+	// HTTP credentials, never exposed through the API or logs
+	BasicAuthUsername string `json:"-"`
+	BasicAuthPassword string `json:"-"`
+	BearerToken       string `json:"-"`
+
+	// TLS client configuration
+	CAFile         string `json:"-"`
+	ClientCertFile string `json:"-"`
+	ClientKeyFile  string `json:"-"`
+	ServerName     string `json:"-"`
+
+	// Outgoing proxy, the url may contain credentials
+	Proxy                string `json:"-"`
+	ProxyFromEnvironment bool   `json:"-"`
+
+	// Response body assertions
+	BodyContains    []string        `json:"-" gorm:"serializer:json"`
+	BodyNotContains []string        `json:"-" gorm:"serializer:json"`
+	BodyRegex       string          `json:"-"`
+	MaxBodySize     int64           `json:"-"`
+	JSONAssertions  []JSONAssertion `json:"-" gorm:"serializer:json"`
+
+	// TCP monitor configuration
+	TCPPayload string `json:"-"`
+	TCPExpect  string `json:"-"`
+
+	// DNS monitor configuration
+	DNSResolver      string   `json:"-"`
+	DNSRecordType    string   `json:"-"`
+	DNSExpected      []string `json:"-" gorm:"serializer:json"`
+	DNSExpectedRegex string   `json:"-"`
+	DNSAnswers       []string `json:"-" gorm:"serializer:json"` // answers of the last successful check
+
+	// Patterns compiled when the configuration is loaded
+	BodyPattern        *regexp.Regexp `json:"-" gorm:"-"`
+	TCPExpectPattern   *regexp.Regexp `json:"-" gorm:"-"`
+	DNSExpectedPattern *regexp.Regexp `json:"-" gorm:"-"`
+}
+
+// JSONAssertion is a rule evaluated on a JSON response body, e.g. $.status == "ok"
+type JSONAssertion struct {
+	Path     string         `json:"path"`
+	Operator string         `json:"operator"`
+	Value    any            `json:"value,omitempty"`
+	Pattern  *regexp.Regexp `json:"-"` // compiled value of a matches assertion
 }
 
 type MonitorHistory struct {
@@ -50,19 +126,105 @@ type MonitorHistory struct {
 	IsUp         bool      `json:"is_up" gorm:"index"`
 	StatusCode   int       `json:"-"`
 	ResponseTime int64     `json:"response_time"` // in milliseconds
+	Maintenance  bool      `json:"maintenance"`   // checked during a maintenance window
 	CreatedAt    time.Time `json:"created_at" gorm:"index"`
 	Monitor      Monitor   `json:"-" gorm:"foreignKey:MonitorID"`
+
+	Addresses []AddressResult `json:"addresses,omitempty" gorm:"serializer:json"` // per address results with AllAddresses
+	Families  []FamilyResult  `json:"families,omitempty" gorm:"serializer:json"`  // per family results with ip_type both
 }
 
 type Incident struct {
-	ID          string        `json:"id" gorm:"primaryKey"`
-	MonitorID   string        `json:"monitor_id" gorm:"index"`
-	IncidentID  uint64        `json:"-"`
-	Type        incident.Type `json:"type" gorm:"index"`
-	Description string        `json:"description"`
-	CreatedAt   time.Time     `json:"created_at"`
-	SolvedAt    *time.Time    `json:"solved_at" gorm:"index"`
-	Monitor     Monitor       `gorm:"foreignKey:MonitorID"`
+	ID          string            `json:"id" gorm:"primaryKey"`
+	MonitorID   string            `json:"monitor_id" gorm:"index"`
+	IncidentID  uint64            `json:"-" gorm:"<-:create"` // set by the outbox once the master created it
+	Type        incident.Type     `json:"type" gorm:"index"`
+	Severity    incident.Severity `json:"severity"`
+	Description string            `json:"description"`
+	CreatedAt   time.Time         `json:"created_at"`
+	SolvedAt    *time.Time        `json:"solved_at" gorm:"index"`
+	Monitor     Monitor           `gorm:"foreignKey:MonitorID"`
+
+	// Follow-up notifications sent while the incident stays open
+	LastNotifiedAt *time.Time `json:"-"`
+	Reminders      int        `json:"-"`
+}
+
+// Outbox actions
+const (
+	OutboxCreateIncident = "create_incident"
+	OutboxUpdateStatus   = "update_status"
+)
+
+// OutboxMessage is a notification to the master that has not been delivered yet.
+// Messages of the same incident are delivered in ID order.
+type OutboxMessage struct {
+	ID            uint64            `json:"id" gorm:"primaryKey;autoIncrement"`
+	IncidentID    string            `json:"incident_id" gorm:"index"`
+	Action        string            `json:"action"`
+	Severity      incident.Severity `json:"severity,omitempty"`
+	Event         string            `json:"event,omitempty"`
+	Status        incident.Status   `json:"status,omitempty"`
+	Attributes    map[string]any    `json:"attributes,omitempty" gorm:"serializer:json"`
+	RemoteID      uint64            `json:"remote_id,omitempty"` // master incident id of a create whose backfill failed
+	Attempts      int               `json:"attempts"`
+	NextAttemptAt time.Time         `json:"next_attempt_at" gorm:"index"`
+	LastError     string            `json:"last_error,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// MonitorConfigColumns are the columns owned by the configuration file.
+// They are overwritten when the configuration is merged into the database,
+// every other column holds monitor state.
+var MonitorConfigColumns = []string{
+	"url",
+	"type",
+	"enabled",
+	"response_time_threshold",
+	"degraded_threshold",
+	"interval",
+	"certificate_monitoring",
+	"certificate_expired_before",
+	"follow_redirects",
+	"accepted_status_codes",
+	"ip_type",
+	"all_addresses",
+	"tags",
+	"method",
+	"headers",
+	"body",
+	"basic_auth_username",
+	"basic_auth_password",
+	"bearer_token",
+	"ca_file",
+	"client_cert_file",
+	"client_key_file",
+	"server_name",
+	"proxy",
+	"proxy_from_environment",
+	"max_retries",
+	"retry_interval",
+	"recovery_threshold",
+	"reminder_interval",
+	"escalate_after",
+	"escalate_to",
+	"flap_window",
+	"flap_threshold",
+	"dns_timeout",
+	"dial_timeout",
+	"tls_handshake_timeout",
+	"response_header_timeout",
+	"body_contains",
+	"body_not_contains",
+	"body_regex",
+	"max_body_size",
+	"json_assertions",
+	"tcp_payload",
+	"tcp_expect",
+	"dns_resolver",
+	"dns_record_type",
+	"dns_expected",
+	"dns_expected_regex",
 }
 
 type Response struct {
@@ -84,6 +246,70 @@ func (m Monitor) IsNotExists() bool {
 	return m.CreatedAt.IsZero()
 }
 
+// ApplyConfig copies the configuration fields of src, keeping the monitor state.
+func (m *Monitor) ApplyConfig(src *Monitor) {
+	m.Type = src.Type
+	m.Enabled = src.Enabled
+	m.Interval = src.Interval
+	m.ResponseTimeThreshold = src.ResponseTimeThreshold
+	m.DegradedThreshold = src.DegradedThreshold
+	m.CertificateMonitoring = src.CertificateMonitoring
+	m.CertificateExpiredBefore = src.CertificateExpiredBefore
+	m.FollowRedirects = src.FollowRedirects
+	m.AcceptedStatusCodes = src.AcceptedStatusCodes
+	m.IPType = src.IPType
+	m.AllAddresses = src.AllAddresses
+	m.Tags = src.Tags
+	m.Method = src.Method
+	m.Headers = src.Headers
+	m.Body = src.Body
+	m.BasicAuthUsername = src.BasicAuthUsername
+	m.BasicAuthPassword = src.BasicAuthPassword
+	m.BearerToken = src.BearerToken
+	m.CAFile = src.CAFile
+	m.ClientCertFile = src.ClientCertFile
+	m.ClientKeyFile = src.ClientKeyFile
+	m.ServerName = src.ServerName
+	m.Proxy = src.Proxy
+	m.ProxyFromEnvironment = src.ProxyFromEnvironment
+	m.MaxRetries = src.MaxRetries
+	m.RetryInterval = src.RetryInterval
+	m.RecoveryThreshold = src.RecoveryThreshold
+	m.ReminderInterval = src.ReminderInterval
+	m.EscalateAfter = src.EscalateAfter
+	m.EscalateTo = src.EscalateTo
+	m.FlapWindow = src.FlapWindow
+	m.FlapThreshold = src.FlapThreshold
+	m.DNSTimeout = src.DNSTimeout
+	m.DialTimeout = src.DialTimeout
+	m.TLSHandshakeTimeout = src.TLSHandshakeTimeout
+	m.ResponseHeaderTimeout = src.ResponseHeaderTimeout
+	m.BodyContains = src.BodyContains
+	m.BodyNotContains = src.BodyNotContains
+	m.BodyRegex = src.BodyRegex
+	m.BodyPattern = src.BodyPattern
+	m.MaxBodySize = src.MaxBodySize
+	m.JSONAssertions = src.JSONAssertions
+	m.TCPPayload = src.TCPPayload
+	m.TCPExpect = src.TCPExpect
+	m.TCPExpectPattern = src.TCPExpectPattern
+	m.DNSResolver = src.DNSResolver
+	m.DNSRecordType = src.DNSRecordType
+	m.DNSExpected = src.DNSExpected
+	m.DNSExpectedRegex = src.DNSExpectedRegex
+	m.DNSExpectedPattern = src.DNSExpectedPattern
+}
+
+// SameConfig reports whether both monitors share the same configuration
+func (m *Monitor) SameConfig(other *Monitor) bool {
+	var a, b Monitor
+	a.URL, b.URL = m.URL, other.URL
+	a.ApplyConfig(m)
+	b.ApplyConfig(other)
+
+	return reflect.DeepEqual(a, b)
+}
+
 func (i Incident) IsExists() bool {
 	return !i.CreatedAt.IsZero()
 }
@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"slices"
+	"time"
+	"uptime-go/internal/helper"
+	"uptime-go/internal/models"
+)
+
+// SetMaintenance replaces the maintenance windows, e.g. after a configuration reload
+func (m *UptimeMonitor) SetMaintenance(windows []models.MaintenanceWindow) {
+	m.maintenanceMutex.Lock()
+	defer m.maintenanceMutex.Unlock()
+
+	m.maintenance = windows
+}
+
+// activeMaintenance returns the maintenance window covering monitor at the
+// given time, nil when there is none.
+func (m *UptimeMonitor) activeMaintenance(monitor *models.Monitor, at time.Time) *models.MaintenanceWindow {
+	m.maintenanceMutex.RLock()
+	defer m.maintenanceMutex.RUnlock()
+
+	for i := range m.maintenance {
+		window := &m.maintenance[i]
+		if windowApplies(window, monitor) && windowActive(window, at) {
+			return window
+		}
+	}
+
+	return nil
+}
+
+// windowApplies reports whether monitor matches the urls or tags of the window
+func windowApplies(window *models.MaintenanceWindow, monitor *models.Monitor) bool {
+	if len(window.URLs) == 0 && len(window.Tags) == 0 {
+		return true
+	}
+
+	for _, pattern := range window.URLs {
+		if helper.MatchGlob(pattern, monitor.URL) {
+			return true
+		}
+	}
+
+	return slices.ContainsFunc(monitor.Tags, func(tag string) bool { return slices.Contains(window.Tags, tag) })
+}
+
+// windowActive reports whether the window covers the given time
+func windowActive(window *models.MaintenanceWindow, at time.Time) bool {
+	if !window.Recurring() {
+		return !at.Before(window.Start) && at.Before(window.End)
+	}
+
+	location := window.Location
+	if location == nil {
+		location = time.Local
+	}
+	local := at.In(location)
+	clock := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+
+	onDay := func(day time.Weekday) bool {
+		return len(window.Weekdays) == 0 || slices.Contains(window.Weekdays, day)
+	}
+
+	if window.From < window.To {
+		return onDay(local.Weekday()) && clock >= window.From && clock < window.To
+	}
+
+	// The window ends the next day
+	yesterday := (local.Weekday() + 6) % 7
+	return (onDay(local.Weekday()) && clock >= window.From) || (onDay(yesterday) && clock < window.To)
+}
@@ -0,0 +1,115 @@
+package monitor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"uptime-go/internal/incident"
+	"uptime-go/internal/models"
+	"uptime-go/internal/net/database"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowActive(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	oneOff := &models.MaintenanceWindow{
+		Location: time.UTC,
+		Start:    time.Date(2025, 6, 1, 22, 0, 0, 0, time.UTC),
+		End:      time.Date(2025, 6, 2, 2, 0, 0, 0, time.UTC),
+	}
+	nightly := &models.MaintenanceWindow{
+		Location: jakarta,
+		Weekdays: []time.Weekday{time.Tuesday},
+		From:     22 * time.Hour,
+		To:       2 * time.Hour,
+	}
+	lunch := &models.MaintenanceWindow{
+		Location: time.UTC,
+		From:     12 * time.Hour,
+		To:       13 * time.Hour,
+	}
+
+	testCases := []struct {
+		name     string
+		window   *models.MaintenanceWindow
+		at       time.Time
+		expected bool
+	}{
+		{"one-off inside", oneOff, time.Date(2025, 6, 2, 1, 0, 0, 0, time.UTC), true},
+		{"one-off end excluded", oneOff, time.Date(2025, 6, 2, 2, 0, 0, 0, time.UTC), false},
+		{"one-off before", oneOff, time.Date(2025, 6, 1, 21, 59, 0, 0, time.UTC), false},
+		// 2025-06-03 is a Tuesday, Jakarta is UTC+7
+		{"recurring start day", nightly, time.Date(2025, 6, 3, 23, 0, 0, 0, jakarta), true},
+		{"recurring next morning", nightly, time.Date(2025, 6, 4, 1, 30, 0, 0, jakarta), true},
+		{"recurring other day", nightly, time.Date(2025, 6, 4, 23, 0, 0, 0, jakarta), false},
+		{"recurring in another zone", nightly, time.Date(2025, 6, 3, 15, 30, 0, 0, time.UTC), true},
+		{"recurring after end", nightly, time.Date(2025, 6, 4, 2, 0, 0, 0, jakarta), false},
+		{"every day", lunch, time.Date(2025, 6, 7, 12, 30, 0, 0, time.UTC), true},
+		{"every day outside", lunch, time.Date(2025, 6, 7, 13, 30, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, windowActive(tc.window, tc.at))
+		})
+	}
+}
+
+func TestWindowApplies(t *testing.T) {
+	monitor := &models.Monitor{URL: "https://api.example.com/health", Tags: []string{"prod"}}
+
+	assert.True(t, windowApplies(&models.MaintenanceWindow{}, monitor))
+	assert.True(t, windowApplies(&models.MaintenanceWindow{URLs: []string{"https://api.example.com/*"}}, monitor))
+	assert.True(t, windowApplies(&models.MaintenanceWindow{Tags: []string{"dev", "prod"}}, monitor))
+	assert.False(t, windowApplies(&models.MaintenanceWindow{URLs: []string{"https://www.example.com/*"}, Tags: []string{"dev"}}, monitor))
+}
+
+func TestCheckWebsiteDuringMaintenance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	db, _ := database.InitializeTestDatabase()
+	uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+	uptimeMonitor.SetMaintenance([]models.MaintenanceWindow{{
+		Name:  "deploy",
+		Tags:  []string{"api"},
+		Start: time.Now().Add(-time.Minute),
+		End:   time.Now().Add(time.Hour),
+	}})
+
+	monitor := &models.Monitor{
+		ID:                    "maintained",
+		URL:                   server.URL,
+		Interval:              time.Minute,
+		ResponseTimeThreshold: 5 * time.Second,
+		Tags:                  []string{"api"},
+	}
+	db.DB.Create(monitor)
+	monitor.MaxRetries = 0
+
+	uptimeMonitor.checkWebsite(monitor)
+
+	stored, err := db.GetMonitorWithHistories(server.URL, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, incident.StatusMAINTENANCE, stored.Status)
+	assert.True(t, stored.Maintenance)
+	assert.False(t, *stored.IsUp)
+	if assert.Len(t, stored.Histories, 1) {
+		assert.True(t, stored.Histories[0].Maintenance)
+	}
+	assert.True(t, db.GetLastIncident(server.URL, incident.UnexpectedStatusCode).IsNotExists())
+
+	// Once the window is over the incident is opened
+	uptimeMonitor.SetMaintenance(nil)
+	monitor.MaxRetries = 0 // reset to the column default when saved
+	uptimeMonitor.checkWebsite(monitor)
+	assert.True(t, db.GetLastIncident(server.URL, incident.UnexpectedStatusCode).IsExists())
+}
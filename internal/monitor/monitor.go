@@ -6,9 +6,12 @@ import (
 	"fmt"
 	stdnet "net"
 	"net/http"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
+	"uptime-go/internal/configuration"
 	"uptime-go/internal/helper"
 	"uptime-go/internal/incident"
 	"uptime-go/internal/models"
@@ -18,91 +21,164 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+// downIncidentTypes are the incident types opened by handleWebsiteDown,
+// all of them are resolved once the monitor is UP again.
+var downIncidentTypes = []incident.Type{
+	incident.UnexpectedStatusCode,
+	incident.Timeout,
+	incident.ConnectionRefused,
+	incident.DNSMismatch,
+	incident.KeywordMismatch,
+	incident.JSONAssertionFailed,
+}
+
 // UptimeMonitor represents a service that periodically checks website uptime
 type UptimeMonitor struct {
-	configs  []*models.Monitor
-	db       *database.Database
-	stopChan chan struct{}
-	wg       sync.WaitGroup
+	configs    []*models.Monitor
+	db         *database.Database
+	scheduler  *Scheduler
+	outbox     *net.Outbox
+	dispatcher *net.Dispatcher
+	mutex      sync.Mutex
+
+	maintenance      []models.MaintenanceWindow
+	maintenanceMutex sync.RWMutex
 }
 
 func NewUptimeMonitor(db *database.Database, configs []*models.Monitor) (*UptimeMonitor, error) {
-	return &UptimeMonitor{
-		configs:  configs,
-		db:       db,
-		stopChan: make(chan struct{}),
-	}, nil
+	m := &UptimeMonitor{
+		configs:     configs,
+		db:          db,
+		outbox:      net.NewOutbox(db),
+		dispatcher:  net.NewDispatcher(configuration.Config.Notifications),
+		maintenance: configuration.Config.Maintenance,
+	}
+
+	m.scheduler = NewScheduler(SchedulerConfig{
+		MaxConcurrentChecks: configuration.Config.MaxConcurrentChecks,
+		StartJitter:         configuration.Config.StartJitter,
+	}, m.checkWebsite)
+
+	return m, nil
 }
 
 func (m *UptimeMonitor) Start() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	log.Info().Msgf("Starting uptime monitoring for %d websites", len(m.configs))
 
-	// Start a goroutine for each website to monitor
+	// Schedule every enabled website on the shared worker pool
 	for _, cfg := range m.configs {
 		if !cfg.Enabled {
 			log.Info().Msgf("%s - skipped because disabled", cfg.URL)
 			continue
 		}
 
-		m.wg.Add(1)
-		go m.monitorWebsite(cfg)
+		m.scheduler.Add(cfg)
 	}
-}
 
-// Shutdown gracefully stops all monitoring goroutines.
-func (m *UptimeMonitor) Shutdown() {
-	log.Info().Msg("Shutting down uptime monitoring...")
-	close(m.stopChan)
-	m.wg.Wait()
-	log.Info().Msg("Uptime monitoring stopped")
+	m.outbox.Start()
+	m.scheduler.Start()
 }
 
-func (m *UptimeMonitor) monitorWebsite(cfg *models.Monitor) {
-	defer m.wg.Done()
-
-	ticker := time.NewTicker(cfg.Interval)
-	defer ticker.Stop()
-
-	// Perform initial check immediately
-	m.checkWebsite(cfg)
-	if cfg.Retries > 0 && cfg.RetryInterval > 0 {
-		// If initial check entered PENDING, switch to retry interval immediately.
-		ticker.Reset(cfg.RetryInterval)
-	}
-
-	for {
-		select {
-		case <-ticker.C:
-			m.checkWebsite(cfg)
-
-			// Adjust next check interval based on status
-			nextInterval := cfg.Interval
-			if cfg.Retries > 0 && cfg.RetryInterval > 0 {
-				// Use faster retry interval when in PENDING state
-				nextInterval = cfg.RetryInterval
-				ticker.Reset(nextInterval)
-				log.Debug().Msgf("%s - Using retry interval: %v", cfg.URL, nextInterval)
-			} else {
-				// Reset to normal interval
-				ticker.Reset(cfg.Interval)
+// Reload replaces the monitored websites with configs. Monitors are matched
+// by URL: removed monitors are unscheduled, new ones are scheduled and
+// monitors whose configuration changed are restarted with the new
+// configuration applied to the running monitor, so the state of a check in
+// progress is kept. Unchanged monitors keep running untouched.
+func (m *UptimeMonitor) Reload(configs []*models.Monitor) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	current := make(map[string]*models.Monitor, len(m.configs))
+	for _, cfg := range m.configs {
+		current[cfg.URL] = cfg
+	}
+
+	var added, removed, changed int
+	merged := make([]*models.Monitor, 0, len(configs))
+
+	for _, cfg := range configs {
+		old, ok := current[cfg.URL]
+		delete(current, cfg.URL)
+
+		switch {
+		case !ok:
+			added++
+		case old.SameConfig(cfg):
+			merged = append(merged, old)
+			continue
+		default:
+			changed++
+			// Remove waits for a running check, whose save would otherwise
+			// overwrite the new configuration
+			m.scheduler.Remove(old)
+			old.ApplyConfig(cfg)
+			if err := m.db.Upsert(old); err != nil {
+				log.Error().Err(err).Str("url", old.URL).Msg("failed to save reloaded monitor")
 			}
+			cfg = old
+		}
 
-		case <-m.stopChan:
-			return
+		if cfg.Enabled {
+			m.scheduler.Add(cfg)
 		}
+		merged = append(merged, cfg)
 	}
+
+	for _, old := range current {
+		removed++
+		m.scheduler.Remove(old)
+	}
+
+	m.configs = merged
+
+	log.Info().
+		Int("added", added).
+		Int("removed", removed).
+		Int("changed", changed).
+		Int("total", len(merged)).
+		Msg("configuration reloaded")
+}
+
+// Shutdown gracefully stops the scheduler and waits for running checks.
+func (m *UptimeMonitor) Shutdown() {
+	log.Info().Msg("Shutting down uptime monitoring...")
+	m.scheduler.Stop()
+	m.outbox.Stop()
+	m.dispatcher.Wait()
+	log.Info().Msg("Uptime monitoring stopped")
 }
 
 // determineStatus implements state-based retry logic (Uptime Kuma approach)
 func determineStatus(isCurrentCheckUp bool, monitor *models.Monitor) string {
 	wasUp := monitor.IsUp != nil && *monitor.IsUp
+	recovering := monitor.Status == incident.StatusRECOVERING
 
 	if isCurrentCheckUp {
-		// Current check succeeded - always UP
+		// A DOWN monitor needs recovery_threshold consecutive successes to be
+		// UP again, also when the outage went through retries, a maintenance
+		// window or flapping since
+		if monitor.RecoveryThreshold > 1 && (monitor.Down || recovering) {
+			monitor.Recoveries++
+			if monitor.Recoveries < monitor.RecoveryThreshold {
+				return incident.StatusRECOVERING
+			}
+		}
+
+		// Current check succeeded - UP
+		monitor.Recoveries = 0
 		return incident.StatusUP
 	}
 
 	// Current check failed
+	monitor.Recoveries = 0
+	if recovering {
+		// Recovery not confirmed, the incident is still open
+		return incident.StatusDOWN
+	}
+
 	if wasUp {
 		// Was UP, now failing - check if retries available
 		if monitor.MaxRetries > 0 && monitor.Retries < monitor.MaxRetries {
@@ -136,28 +212,67 @@ func (m *UptimeMonitor) checkWebsite(monitor *models.Monitor) {
 		RefreshInterval:       monitor.Interval,
 		Timeout:               monitor.ResponseTimeThreshold,
 		FollowRedirects:       monitor.FollowRedirects,
+		AcceptedStatusCodes:   monitor.AcceptedStatusCodes,
 		SkipSSL:               !monitor.CertificateMonitoring,
 		IPType:                monitor.IPType,
+		AllAddresses:          monitor.AllAddresses,
+		Method:                monitor.Method,
+		Headers:               monitor.Headers,
+		Body:                  monitor.Body,
+		BasicAuthUsername:     monitor.BasicAuthUsername,
+		BasicAuthPassword:     monitor.BasicAuthPassword,
+		BearerToken:           monitor.BearerToken,
+		CAFile:                monitor.CAFile,
+		ClientCertFile:        monitor.ClientCertFile,
+		ClientKeyFile:         monitor.ClientKeyFile,
+		ServerName:            monitor.ServerName,
+		Proxy:                 monitor.Proxy,
+		ProxyFromEnvironment:  monitor.ProxyFromEnvironment,
 		DNSTimeout:            monitor.DNSTimeout,
 		DialTimeout:           monitor.DialTimeout,
 		TLSHandshakeTimeout:   monitor.TLSHandshakeTimeout,
 		ResponseHeaderTimeout: monitor.ResponseHeaderTimeout,
+		BodyContains:          monitor.BodyContains,
+		BodyNotContains:       monitor.BodyNotContains,
+		BodyRegex:             monitor.BodyPattern,
+		MaxBodySize:           monitor.MaxBodySize,
+		JSONAssertions:        monitor.JSONAssertions,
+		TCPPayload:            monitor.TCPPayload,
+		TCPExpect:             monitor.TCPExpectPattern,
+		DNSResolver:           monitor.DNSResolver,
+		DNSRecordType:         monitor.DNSRecordType,
+		DNSExpected:           monitor.DNSExpected,
+		DNSExpectedRegex:      monitor.DNSExpectedPattern,
+	}
+
+	checker, err := net.GetChecker(monitor.Type)
+	if err != nil {
+		log.Error().Err(err).Msgf("%s - skipped check", monitor.URL)
+		return
 	}
 
-	result, err := nc.CheckWebsite()
+	result, err := checker.Check(nc)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error checking %s: %v", monitor.URL, result.ErrorMessage)
 	}
+	countAddressFailures(monitor.Addresses, result.Addresses)
+	countFamilyFailures(monitor.Families, result.Families)
 
 	// Log phase timings for debugging
 	if result.DNSTime > 0 || result.ConnectTime > 0 {
-		log.Debug().Msgf("%s - Timings: DNS=%v, Connect=%v, FirstByte=%v, Total=%v",
-			monitor.URL, result.DNSTime, result.ConnectTime,
+		log.Debug().Msgf("%s - Timings: DNS=%v, Connect=%v, Proxy=%v, FirstByte=%v, Total=%v",
+			monitor.URL, result.DNSTime, result.ConnectTime, result.ProxyTime,
 			result.FirstByteTime, result.ResponseTime)
 	}
 
 	// Determine new status based on check result
 	newStatus := determineStatus(result.IsUp, monitor)
+	if newStatus == incident.StatusUP && isDegraded(result, monitor) {
+		newStatus = incident.StatusDEGRADED
+	}
+	if (newStatus == incident.StatusUP || newStatus == incident.StatusDEGRADED) && len(partialFailures(result)) > 0 {
+		newStatus = incident.StatusPARTIAL
+	}
 
 	if !result.IsUp && result.ErrorMessage == "" {
 		if result.StatusCode != 0 {
@@ -170,23 +285,76 @@ func (m *UptimeMonitor) checkWebsite(monitor *models.Monitor) {
 	}
 
 	now := time.Now()
+	window := m.activeMaintenance(monitor, now)
+	if window != nil {
+		newStatus = incident.StatusMAINTENANCE
+	} else if m.handleFlapping(monitor, result) {
+		newStatus = incident.StatusFLAPPING
+	}
+
+	if monitor.CertificateMonitoring && newStatus != incident.StatusMAINTENANCE {
+		m.handleCertificate(monitor, result)
+	}
+
 	switch newStatus {
-	case incident.StatusUP:
+	case incident.StatusMAINTENANCE:
+		// Planned maintenance - incidents are neither opened nor resolved
+		monitor.Retries = 0
+		monitor.Recoveries = 0
+		log.Info().Msgf("%s - MAINTENANCE (%s) - Up: %t - Response time: %v | Error: %s",
+			monitor.URL, window.Name, result.IsUp, result.ResponseTime, result.ErrorMessage)
+
+	case incident.StatusFLAPPING:
+		// Per-transition alerts are suppressed until the monitor is stable again
+		if result.IsUp || monitor.Retries >= monitor.MaxRetries {
+			monitor.Retries = 0
+		}
+		if result.IsUp && monitor.CertificateMonitoring {
+			m.handleSSL(monitor, result)
+		}
+		log.Warn().Msgf("%s - FLAPPING - Up: %t - Response time: %v | Error: %s",
+			monitor.URL, result.IsUp, result.ResponseTime, result.ErrorMessage)
+
+	case incident.StatusUP, incident.StatusDEGRADED, incident.StatusPARTIAL:
 		// Website is UP
 		monitor.Retries = 0 // Reset retries
+		monitor.Down = false
 		if monitor.LastUp == nil {
 			monitor.LastUp = &now
 		}
 
-		m.resolveIncidents(monitor, incident.UnexpectedStatusCode)
-		m.resolveIncidents(monitor, incident.Timeout)
+		for _, incidentType := range downIncidentTypes {
+			m.resolveIncidents(monitor, result, incidentType)
+		}
 		if monitor.CertificateMonitoring {
 			m.handleSSL(monitor, result)
 		}
 
+		m.handlePartialOutage(monitor, result)
+		m.handleFamilies(monitor, result)
+
+		if isDegraded(result, monitor) {
+			m.handleSlowResponse(monitor, result)
+			log.Warn().Msgf("%s - DEGRADED - Response time: %v exceeds %v - Status: %d",
+				monitor.URL, result.ResponseTime, monitor.DegradedThreshold, result.StatusCode)
+			break
+		}
+
+		m.resolveIncidents(monitor, result, incident.SlowResponse)
+		if newStatus == incident.StatusPARTIAL {
+			log.Warn().Msgf("%s - PARTIAL - Down: %s - Response time: %v - Status: %d",
+				monitor.URL, strings.Join(partialFailures(result), ", "), result.ResponseTime, result.StatusCode)
+			break
+		}
 		log.Info().Msgf("%s - UP - Response time: %v - Status: %d",
 			monitor.URL, result.ResponseTime, result.StatusCode)
 
+	case incident.StatusRECOVERING:
+		// Website is back but the recovery is not confirmed yet - keep incidents open
+		monitor.Retries = 0
+		log.Info().Msgf("%s - RECOVERING - Success %d/%d | Next check in %v | Response time: %v - Status: %d",
+			monitor.URL, monitor.Recoveries, monitor.RecoveryThreshold, monitor.RetryInterval, result.ResponseTime, result.StatusCode)
+
 	case incident.StatusPENDING:
 		// Website failed but we're retrying - don't trigger incident yet
 		log.Warn().Msgf("%s - PENDING - Retry %d/%d | Next retry in %v | Error: %s",
@@ -195,6 +363,7 @@ func (m *UptimeMonitor) checkWebsite(monitor *models.Monitor) {
 	default:
 		// Website is DOWN (after all retries exhausted)
 		monitor.Retries = 0 // Reset for next cycle
+		monitor.Down = true
 		m.handleWebsiteDown(monitor, result, err)
 		log.Error().Msgf("%s - DOWN - All retries exhausted | Error: %s",
 			monitor.URL, result.ErrorMessage)
@@ -203,15 +372,29 @@ func (m *UptimeMonitor) checkWebsite(monitor *models.Monitor) {
 	// Update monitor state
 	responseTime := result.ResponseTime.Milliseconds()
 	monitor.UpdatedAt = result.LastCheck
+	monitor.Status = newStatus
+	monitor.Maintenance = window != nil
 	monitor.IsUp = &result.IsUp
 	monitor.StatusCode = &result.StatusCode
 	monitor.ResponseTime = &responseTime
 	monitor.CertificateExpiredDate = result.SSLExpiredDate
+	if result.Certificate != nil {
+		monitor.Certificate = result.Certificate
+	}
+	if result.IsUp && result.Answers != nil {
+		// Keep the last known-good answers, a mismatch is reported against them
+		monitor.DNSAnswers = result.Answers
+	}
+	monitor.Addresses = result.Addresses
+	monitor.Families = result.Families
 	monitor.Histories = []models.MonitorHistory{
 		{
 			IsUp:         result.IsUp,
 			StatusCode:   result.StatusCode,
 			ResponseTime: responseTime,
+			Maintenance:  window != nil,
+			Addresses:    result.Addresses,
+			Families:     result.Families,
 		},
 	}
 
@@ -220,6 +403,80 @@ func (m *UptimeMonitor) checkWebsite(monitor *models.Monitor) {
 	}
 }
 
+// isDegraded reports whether a successful check exceeded the soft response time threshold
+func isDegraded(result *net.CheckResults, monitor *models.Monitor) bool {
+	return result.IsUp && monitor.DegradedThreshold > 0 && result.ResponseTime > monitor.DegradedThreshold
+}
+
+// handleFlapping tracks the state change rate over the last flap_window
+// checks. The monitor enters FLAPPING once the rate reaches flap_threshold
+// percent and leaves it when the rate falls below half of it, opening and
+// resolving a single flapping incident. It reports whether the monitor is
+// flapping.
+func (m *UptimeMonitor) handleFlapping(monitor *models.Monitor, result *net.CheckResults) bool {
+	if monitor.FlapThreshold <= 0 {
+		if monitor.Flapping {
+			monitor.Flapping = false
+			m.resolveIncidents(monitor, result, incident.Flapping)
+		}
+		return false
+	}
+
+	histories, err := m.db.GetRecentHistories(monitor.ID, monitor.FlapWindow-1)
+	if err != nil {
+		log.Error().Err(err).Msgf("%s - failed to evaluate flapping", monitor.URL)
+		return monitor.Flapping
+	}
+
+	states := []bool{result.IsUp}
+	for _, history := range histories {
+		states = append(states, history.IsUp)
+	}
+	rate := flapRate(states)
+
+	switch {
+	case !monitor.Flapping && len(states) >= monitor.FlapWindow && rate >= monitor.FlapThreshold:
+		monitor.Flapping = true
+
+		inc := &models.Incident{
+			ID:          helper.GenerateRandomID(),
+			MonitorID:   monitor.ID,
+			Type:        incident.Flapping,
+			Severity:    incident.MEDIUM,
+			Description: fmt.Sprintf("State changed in %.0f%% of the last %d checks, alerts are suppressed until it is stable", rate, len(states)),
+			Monitor:     *monitor,
+		}
+		m.db.DB.Create(inc)
+		m.notify(monitor, result, inc, incident.EventWebsiteFlapping, map[string]any{
+			"state_change_rate": rate,
+			"flap_window":       len(states),
+		})
+		log.Warn().Msgf("%s - New Incident detected! - Type: %s", monitor.URL, inc.Type)
+
+	case monitor.Flapping && rate < monitor.FlapThreshold/2:
+		monitor.Flapping = false
+		m.resolveIncidents(monitor, result, incident.Flapping)
+	}
+
+	return monitor.Flapping
+}
+
+// flapRate returns the percentage of consecutive states that differ
+func flapRate(states []bool) float64 {
+	if len(states) < 2 {
+		return 0
+	}
+
+	changes := 0
+	for i := 1; i < len(states); i++ {
+		if states[i] != states[i-1] {
+			changes++
+		}
+	}
+
+	return float64(changes) * 100 / float64(len(states)-1)
+}
+
 func (m *UptimeMonitor) handleWebsiteDown(monitor *models.Monitor, result *net.CheckResults, err error) (bool, incident.Type) {
 	// return true if new incident created; else false, incident type
 
@@ -250,6 +507,28 @@ func (m *UptimeMonitor) handleWebsiteDown(monitor *models.Monitor, result *net.C
 			if description == "" {
 				description = fmt.Sprintf("Request timed out after %v: %s", monitor.ResponseTimeThreshold, monitor.URL)
 			}
+		} else if errors.Is(err, net.ErrDNSMismatch) {
+			incidentType = incident.DNSMismatch
+			attributes["previous_answers"] = monitor.DNSAnswers
+			attributes["answers"] = result.Answers
+			attributes["expected"] = monitor.DNSExpected
+			if monitor.DNSExpectedRegex != "" {
+				attributes["expected_regex"] = monitor.DNSExpectedRegex
+			}
+		} else if errors.Is(err, net.ErrKeywordMismatch) {
+			incidentType = incident.KeywordMismatch
+			attributes["body_snippet"] = result.BodySnippet
+		} else if errors.Is(err, net.ErrJSONAssertion) {
+			incidentType = incident.JSONAssertionFailed
+			attributes["failed_assertions"] = result.FailedAssertions
+			attributes["body_snippet"] = result.BodySnippet
+			description = fmt.Sprintf("%d JSON assertion(s) failed for %s:\n- %s",
+				len(result.FailedAssertions), monitor.URL, strings.Join(result.FailedAssertions, "\n- "))
+		} else if errors.Is(err, syscall.ECONNREFUSED) {
+			incidentType = incident.ConnectionRefused
+			if description == "" {
+				description = fmt.Sprintf("Connection refused by %s", monitor.URL)
+			}
 		} else {
 			if description == "" {
 				description = fmt.Sprintf("An unexpected error occurred at %s: %v", monitor.URL, err)
@@ -261,6 +540,7 @@ func (m *UptimeMonitor) handleWebsiteDown(monitor *models.Monitor, result *net.C
 
 	lastIncident := m.db.GetLastIncident(monitor.URL, incidentType)
 	if lastIncident.IsExists() {
+		m.followUp(monitor, result, lastIncident, incident.EventWebsiteDown, attributes)
 		return false, incidentType // Incident already recorded
 	}
 
@@ -268,17 +548,15 @@ func (m *UptimeMonitor) handleWebsiteDown(monitor *models.Monitor, result *net.C
 		ID:          helper.GenerateRandomID(),
 		MonitorID:   monitor.ID,
 		Type:        incidentType,
+		Severity:    incident.HIGH,
 		Description: description,
 		Monitor:     *monitor,
 	}
 
-	if id, err := net.NotifyIncident(inc, incident.HIGH, incident.EventWebsiteDown, attributes); err == nil {
-		inc.IncidentID = id
-	}
-
 	now := time.Now()
 	monitor.LastDown = &now
 	m.db.DB.Create(inc)
+	m.notify(monitor, result, inc, incident.EventWebsiteDown, attributes)
 	log.Warn().Msgf(
 		"%s - New Incident detected! - Type: %s",
 		monitor.URL, inc.Type,
@@ -287,7 +565,203 @@ func (m *UptimeMonitor) handleWebsiteDown(monitor *models.Monitor, result *net.C
 	return true, incidentType
 }
 
-func (m *UptimeMonitor) resolveIncidents(monitor *models.Monitor, incidentType incident.Type) bool {
+func (m *UptimeMonitor) handleSlowResponse(monitor *models.Monitor, result *net.CheckResults) bool {
+	// return true if new incident created; else false
+
+	attributes := map[string]any{
+		"status_code":        result.StatusCode,
+		"response_time":      result.ResponseTime.Seconds(),
+		"degraded_threshold": monitor.DegradedThreshold.Seconds(),
+	}
+
+	lastIncident := m.db.GetLastIncident(monitor.URL, incident.SlowResponse)
+	if lastIncident.IsExists() {
+		m.followUp(monitor, result, lastIncident, incident.EventWebsiteDegraded, attributes)
+		return false // Incident already recorded
+	}
+
+	inc := &models.Incident{
+		ID:          helper.GenerateRandomID(),
+		MonitorID:   monitor.ID,
+		Type:        incident.SlowResponse,
+		Severity:    incident.MEDIUM,
+		Description: fmt.Sprintf("Response time %v exceeded degraded threshold %v", result.ResponseTime.Round(time.Millisecond), monitor.DegradedThreshold),
+		Monitor:     *monitor,
+	}
+
+	m.db.DB.Create(inc)
+	m.notify(monitor, result, inc, incident.EventWebsiteDegraded, attributes)
+	log.Warn().Msgf("%s - New Incident detected! - Type: %s", monitor.URL, inc.Type)
+
+	return true
+}
+
+// handlePartialOutage opens a partial outage incident once an address of the
+// host failed more than max_retries consecutive checks and resolves it once
+// all of them are up.
+func (m *UptimeMonitor) handlePartialOutage(monitor *models.Monitor, result *net.CheckResults) bool {
+	// return true if new incident created; else false
+
+	failed := failedAddresses(result)
+	if len(failed) == 0 {
+		m.resolveIncidents(monitor, result, incident.PartialOutage)
+		return false
+	}
+
+	attributes := map[string]any{
+		"failed_addresses": strings.Join(failed, ", "),
+		"addresses":        len(result.Addresses),
+	}
+
+	lastIncident := m.db.GetLastIncident(monitor.URL, incident.PartialOutage)
+	if lastIncident.IsExists() {
+		m.followUp(monitor, result, lastIncident, incident.EventWebsitePartialOutage, attributes)
+		return false // Incident already recorded
+	}
+
+	if !addressFailureConfirmed(result, monitor.MaxRetries) {
+		// Retrying - don't trigger incident yet
+		return false
+	}
+
+	inc := &models.Incident{
+		ID:          helper.GenerateRandomID(),
+		MonitorID:   monitor.ID,
+		Type:        incident.PartialOutage,
+		Severity:    incident.HIGH,
+		Description: fmt.Sprintf("%d of %d addresses are down: %s", len(failed), len(result.Addresses), strings.Join(failed, ", ")),
+		Monitor:     *monitor,
+	}
+
+	m.db.DB.Create(inc)
+	m.notify(monitor, result, inc, incident.EventWebsitePartialOutage, attributes)
+	log.Warn().Msgf("%s - New Incident detected! - Type: %s", monitor.URL, inc.Type)
+
+	return true
+}
+
+// failedAddresses lists the addresses that are down
+func failedAddresses(result *net.CheckResults) []string {
+	var failed []string
+	for _, address := range result.Addresses {
+		if !address.IsUp {
+			failed = append(failed, address.IP)
+		}
+	}
+	return failed
+}
+
+// countAddressFailures carries the consecutive failure count of every
+// address over from the previous check
+func countAddressFailures(previous, current []models.AddressResult) {
+	failures := make(map[string]int, len(previous))
+	for _, address := range previous {
+		failures[address.IP] = address.Failures
+	}
+
+	for i := range current {
+		if !current[i].IsUp {
+			current[i].Failures = failures[current[i].IP] + 1
+		}
+	}
+}
+
+// addressFailureConfirmed reports whether an address is still down after
+// maxRetries retries
+func addressFailureConfirmed(result *net.CheckResults, maxRetries int) bool {
+	for _, address := range result.Addresses {
+		if !address.IsUp && address.Failures > maxRetries {
+			return true
+		}
+	}
+	return false
+}
+
+// familyIncidentTypes are the incidents opened while a single IP family fails
+var familyIncidentTypes = map[string]incident.Type{
+	"ipv4": incident.IPv4Unreachable,
+	"ipv6": incident.IPv6Unreachable,
+}
+
+// handleFamilies opens an ipv4_unreachable or ipv6_unreachable incident once
+// the family failed more than max_retries consecutive checks with ip_type
+// both, and resolves it once the family is up or no longer checked.
+func (m *UptimeMonitor) handleFamilies(monitor *models.Monitor, result *net.CheckResults) {
+	for _, family := range []string{"ipv4", "ipv6"} {
+		incidentType := familyIncidentTypes[family]
+		familyResult := findFamily(result, family)
+		if familyResult == nil || familyResult.IsUp {
+			m.resolveIncidents(monitor, result, incidentType)
+			continue
+		}
+
+		attributes := map[string]any{
+			"family":        family,
+			"error_message": familyResult.Error,
+		}
+
+		lastIncident := m.db.GetLastIncident(monitor.URL, incidentType)
+		if lastIncident.IsExists() {
+			m.followUp(monitor, result, lastIncident, incident.EventWebsitePartialOutage, attributes)
+			continue // Incident already recorded
+		}
+
+		if familyResult.Failures <= monitor.MaxRetries {
+			continue // Retrying - don't trigger incident yet
+		}
+
+		inc := &models.Incident{
+			ID:          helper.GenerateRandomID(),
+			MonitorID:   monitor.ID,
+			Type:        incidentType,
+			Severity:    incident.HIGH,
+			Description: fmt.Sprintf("Unreachable over %s: %s", family, familyResult.Error),
+			Monitor:     *monitor,
+		}
+
+		m.db.DB.Create(inc)
+		m.notify(monitor, result, inc, incident.EventWebsitePartialOutage, attributes)
+		log.Warn().Msgf("%s - New Incident detected! - Type: %s", monitor.URL, inc.Type)
+	}
+}
+
+// countFamilyFailures carries the consecutive failure count of every IP
+// family over from the previous check
+func countFamilyFailures(previous, current []models.FamilyResult) {
+	failures := make(map[string]int, len(previous))
+	for _, family := range previous {
+		failures[family.Family] = family.Failures
+	}
+
+	for i := range current {
+		if !current[i].IsUp {
+			current[i].Failures = failures[current[i].Family] + 1
+		}
+	}
+}
+
+func findFamily(result *net.CheckResults, family string) *models.FamilyResult {
+	for i := range result.Families {
+		if result.Families[i].Family == family {
+			return &result.Families[i]
+		}
+	}
+	return nil
+}
+
+// partialFailures lists the IP families and addresses that are down while
+// the monitor is up
+func partialFailures(result *net.CheckResults) []string {
+	var failed []string
+	for _, family := range result.Families {
+		if !family.IsUp {
+			failed = append(failed, family.Family)
+		}
+	}
+	return append(failed, failedAddresses(result)...)
+}
+
+func (m *UptimeMonitor) resolveIncidents(monitor *models.Monitor, result *net.CheckResults, incidentType incident.Type) bool {
 	// return true if incident solved; else false
 
 	now := time.Now()
@@ -297,7 +771,7 @@ func (m *UptimeMonitor) resolveIncidents(monitor *models.Monitor, incidentType i
 		monitor.LastUp = &now
 		m.db.Upsert(lastIncident)
 		log.Info().Msgf("%s - Incident Solved - Type: %s - Downtime: %s", monitor.URL, incidentType, time.Since(lastIncident.CreatedAt))
-		net.UpdateIncidentStatus(lastIncident, incident.Resolved)
+		m.notifyResolved(monitor, result, lastIncident)
 
 		return true
 	}
@@ -325,10 +799,9 @@ func (m *UptimeMonitor) handleSSL(monitor *models.Monitor, result *net.CheckResu
 		if lastIncident.IsExists() && lastIncident.Description == "Certificate almost expired" {
 			log.Warn().Msgf("%s - Certificate expired - [%s]", monitor.URL, result.SSLExpiredDate)
 			lastIncident.Description = "Certificate expired"
-			if id, err := net.NotifyIncident(lastIncident, incident.HIGH, incident.EventWebsiteCertificateExpired, attr); err == nil {
-				lastIncident.IncidentID = id
-			}
+			lastIncident.Severity = incident.HIGH
 			m.db.Upsert(lastIncident)
+			m.notify(monitor, result, lastIncident, incident.EventWebsiteCertificateExpired, attr)
 			return true
 		}
 
@@ -339,13 +812,12 @@ func (m *UptimeMonitor) handleSSL(monitor *models.Monitor, result *net.CheckResu
 				ID:          helper.GenerateRandomID(),
 				MonitorID:   monitor.ID,
 				Type:        incident.SSLExpired,
+				Severity:    incident.HIGH,
 				Description: "Certificate expired",
 				Monitor:     *monitor,
 			}
-			if id, err := net.NotifyIncident(inc, incident.HIGH, incident.EventWebsiteCertificateExpired, attr); err == nil {
-				inc.IncidentID = id
-			}
 			m.db.DB.Create(inc)
+			m.notify(monitor, result, inc, incident.EventWebsiteCertificateExpired, attr)
 			return true
 		}
 
@@ -365,13 +837,12 @@ func (m *UptimeMonitor) handleSSL(monitor *models.Monitor, result *net.CheckResu
 				ID:          helper.GenerateRandomID(),
 				MonitorID:   monitor.ID,
 				Type:        incident.SSLExpired,
+				Severity:    incident.INFO,
 				Description: "Certificate almost expired",
 				Monitor:     *monitor,
 			}
-			if id, err := net.NotifyIncident(inc, incident.INFO, incident.EventWebsiteCertificateExpired, attr); err == nil {
-				inc.IncidentID = id
-			}
 			m.db.DB.Create(inc)
+			m.notify(monitor, result, inc, incident.EventWebsiteCertificateExpired, attr)
 			return true
 		}
 
@@ -379,16 +850,179 @@ func (m *UptimeMonitor) handleSSL(monitor *models.Monitor, result *net.CheckResu
 	}
 
 	if lastIncident.IsExists() {
-		// Manual resolve
-		// if lastIncident.IncidentID != 0 {
-		// 	net.UpdateIncidentStatus(lastIncident, incident.Resolved)
-		// }
-
+		// Manual resolve on the master, the channels still get the resolution
 		lastIncident.SolvedAt = &now
 		m.db.Upsert(lastIncident)
+		m.dispatchResolved(monitor, result, lastIncident)
 		log.Info().Msgf("%s - SSL Updated", monitor.URL)
 		return true
 	}
 
 	return false
 }
+
+// handleCertificate opens an incident for every problem found by the TLS
+// inspection and resolves the incidents whose problem is gone. Expiry of the
+// leaf certificate is handled by handleSSL.
+func (m *UptimeMonitor) handleCertificate(monitor *models.Monitor, result *net.CheckResults) {
+	info := result.Certificate
+	if info == nil || !info.Verified {
+		return
+	}
+
+	untrusted := fmt.Sprintf("Certificate chain is not trusted: %s", info.ChainError)
+	if info.SelfSigned {
+		untrusted = "Certificate is self-signed"
+	}
+
+	checks := []struct {
+		incidentType incident.Type
+		severity     incident.Severity
+		failed       bool
+		description  string
+	}{
+		{
+			incidentType: incident.CertificateHostnameMismatch,
+			severity:     incident.HIGH,
+			failed:       !info.HostnameValid,
+			description:  fmt.Sprintf("Certificate is not valid for %s, it covers: %s", monitor.URL, strings.Join(append(info.DNSNames, info.IPAddresses...), ", ")),
+		},
+		{
+			incidentType: incident.CertificateUntrusted,
+			severity:     incident.HIGH,
+			failed:       !info.ChainTrusted,
+			description:  untrusted,
+		},
+		{
+			incidentType: incident.CertificateWeakKey,
+			severity:     incident.MEDIUM,
+			failed:       info.WeakKey,
+			description:  fmt.Sprintf("Certificate uses a weak %d bit %s key", info.KeySize, info.KeyType),
+		},
+		{
+			incidentType: incident.CertificateIntermediateExpired,
+			severity:     incident.HIGH,
+			failed:       len(info.ExpiredIntermediates) > 0,
+			description:  fmt.Sprintf("Expired intermediate certificate: %s", strings.Join(info.ExpiredIntermediates, "; ")),
+		},
+	}
+
+	attributes := map[string]any{
+		"subject": info.Subject,
+		"issuer":  info.Issuer,
+		"serial":  info.SerialNumber,
+	}
+
+	for _, check := range checks {
+		if !check.failed {
+			m.resolveIncidents(monitor, result, check.incidentType)
+			continue
+		}
+
+		if m.db.GetLastIncident(monitor.URL, check.incidentType).IsExists() {
+			continue // Incident already recorded
+		}
+
+		inc := &models.Incident{
+			ID:          helper.GenerateRandomID(),
+			MonitorID:   monitor.ID,
+			Type:        check.incidentType,
+			Severity:    check.severity,
+			Description: check.description,
+			Monitor:     *monitor,
+		}
+		m.db.DB.Create(inc)
+		m.notify(monitor, result, inc, incident.EventWebsiteCertificateInvalid, attributes)
+		log.Warn().Msgf("%s - New Incident detected! - Type: %s", monitor.URL, inc.Type)
+	}
+}
+
+// notify delivers a new or updated incident to the master and to the
+// notification channels its routes select.
+func (m *UptimeMonitor) notify(monitor *models.Monitor, result *net.CheckResults, inc *models.Incident, event string, attributes map[string]any) {
+	m.outbox.EnqueueIncident(inc, inc.Severity, event, attributes)
+	m.dispatch(&net.Notification{
+		Monitor:    monitor,
+		Result:     result,
+		Incident:   inc,
+		Severity:   inc.Severity,
+		Event:      event,
+		Attributes: attributes,
+	})
+}
+
+// followUp re-notifies the channels about an incident that is still open,
+// every reminder_interval and once its severity is raised to escalate_to
+// after escalate_after. Both clocks are stored on the incident, so a restart
+// does not reset them. The master is not notified again.
+func (m *UptimeMonitor) followUp(monitor *models.Monitor, result *net.CheckResults, inc *models.Incident, event string, attributes map[string]any) bool {
+	now := time.Now()
+
+	lastNotified := inc.CreatedAt
+	if inc.LastNotifiedAt != nil {
+		lastNotified = *inc.LastNotifiedAt
+	}
+
+	remind := monitor.ReminderInterval > 0 && now.Sub(lastNotified) >= monitor.ReminderInterval
+	escalate := monitor.EscalateAfter > 0 && now.Sub(inc.CreatedAt) >= monitor.EscalateAfter &&
+		inc.Severity.Level() < monitor.EscalateTo.Level()
+	if !remind && !escalate {
+		return false
+	}
+
+	if escalate {
+		attributes["escalated_from"] = string(inc.Severity)
+		inc.Severity = monitor.EscalateTo
+		log.Warn().Msgf("%s - Incident escalated to %s - Type: %s", monitor.URL, inc.Severity, inc.Type)
+	}
+
+	if remind {
+		inc.Reminders++
+	}
+	inc.LastNotifiedAt = &now
+	if err := m.db.Upsert(inc); err != nil {
+		log.Error().Err(err).Msgf("%s - failed to save incident follow-up", monitor.URL)
+	}
+
+	m.dispatch(&net.Notification{
+		Monitor:    monitor,
+		Result:     result,
+		Incident:   inc,
+		Severity:   inc.Severity,
+		Event:      event,
+		Attributes: attributes,
+		Reminder:   inc.Reminders,
+		Time:       now,
+	})
+
+	return true
+}
+
+// notifyResolved delivers an incident resolution to the master and to the
+// notification channels its routes select.
+func (m *UptimeMonitor) notifyResolved(monitor *models.Monitor, result *net.CheckResults, inc *models.Incident) {
+	m.outbox.EnqueueStatus(inc, incident.Resolved)
+	m.dispatchResolved(monitor, result, inc)
+}
+
+// dispatchResolved delivers an incident resolution to the notification
+// channels only.
+func (m *UptimeMonitor) dispatchResolved(monitor *models.Monitor, result *net.CheckResults, inc *models.Incident) {
+	m.dispatch(&net.Notification{
+		Monitor:  monitor,
+		Result:   result,
+		Incident: inc,
+		Severity: inc.Severity,
+		Resolved: true,
+		Time:     *inc.SolvedAt,
+	})
+}
+
+// dispatch hands n to the notification channels. They render it in the
+// background, so they get copies of the monitor and the incident, which the
+// next checks keep updating.
+func (m *UptimeMonitor) dispatch(n *net.Notification) {
+	monitor, inc := *n.Monitor, *n.Incident
+	n.Monitor, n.Incident = &monitor, &inc
+	m.dispatcher.Dispatch(n)
+}
@@ -2,11 +2,15 @@ package monitor
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
+	"uptime-go/internal/configuration"
 	"uptime-go/internal/incident"
 	"uptime-go/internal/models"
 	"uptime-go/internal/net"
@@ -49,6 +53,38 @@ func TestMonitorHandleWebsiteDown(t *testing.T) {
 			expectedResult:       true,
 			expectedIncidentType: incident.UnexpectedStatusCode,
 		},
+		{
+			name:                 "new connection refused incident",
+			monitor:              models.Monitor{URL: "tcp://127.0.0.1:22"},
+			checkResult:          net.CheckResults{},
+			err:                  fmt.Errorf("TCP connection failed: %w", syscall.ECONNREFUSED),
+			expectedResult:       true,
+			expectedIncidentType: incident.ConnectionRefused,
+		},
+		{
+			name:                 "new dns mismatch incident",
+			monitor:              models.Monitor{URL: "dns://example.com", DNSAnswers: []string{"192.0.2.10"}},
+			checkResult:          net.CheckResults{Answers: []string{"198.51.100.7"}},
+			err:                  fmt.Errorf("%w for A example.com", net.ErrDNSMismatch),
+			expectedResult:       true,
+			expectedIncidentType: incident.DNSMismatch,
+		},
+		{
+			name:                 "new keyword mismatch incident",
+			monitor:              models.Monitor{URL: "https://example.com"},
+			checkResult:          net.CheckResults{StatusCode: http.StatusOK, BodySnippet: "<h1>Maintenance</h1>"},
+			err:                  fmt.Errorf("%w: body does not contain \"ok\"", net.ErrKeywordMismatch),
+			expectedResult:       true,
+			expectedIncidentType: incident.KeywordMismatch,
+		},
+		{
+			name:                 "new json assertion incident",
+			monitor:              models.Monitor{URL: "https://example.com/health"},
+			checkResult:          net.CheckResults{StatusCode: http.StatusOK, FailedAssertions: []string{`$.status == "ok": got "degraded"`}},
+			err:                  fmt.Errorf("%w: $.status", net.ErrJSONAssertion),
+			expectedResult:       true,
+			expectedIncidentType: incident.JSONAssertionFailed,
+		},
 		{
 			name:        "incident already exists",
 			monitor:     models.Monitor{URL: "https://example.com"},
@@ -85,11 +121,12 @@ func TestDetermineStatus(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name            string
-		isCurrentUp     bool
-		monitor         models.Monitor
-		expectedStatus  string
-		expectedRetries int
+		name               string
+		isCurrentUp        bool
+		monitor            models.Monitor
+		expectedStatus     string
+		expectedRetries    int
+		expectedRecoveries int
 	}{
 		{
 			name:            "current up always up",
@@ -140,6 +177,50 @@ func TestDetermineStatus(t *testing.T) {
 			expectedStatus:  incident.StatusDOWN,
 			expectedRetries: 3,
 		},
+		{
+			name:               "was down, first success below recovery threshold -> recovering",
+			isCurrentUp:        true,
+			monitor:            models.Monitor{IsUp: boolPtr(false), Status: incident.StatusDOWN, Down: true, RecoveryThreshold: 3},
+			expectedStatus:     incident.StatusRECOVERING,
+			expectedRecoveries: 1,
+		},
+		{
+			name:               "recovering, threshold reached -> up",
+			isCurrentUp:        true,
+			monitor:            models.Monitor{IsUp: boolPtr(true), Status: incident.StatusRECOVERING, RecoveryThreshold: 3, Recoveries: 2},
+			expectedStatus:     incident.StatusUP,
+			expectedRecoveries: 0,
+		},
+		{
+			name:               "recovering, failure -> down",
+			isCurrentUp:        false,
+			monitor:            models.Monitor{IsUp: boolPtr(true), Status: incident.StatusRECOVERING, RecoveryThreshold: 3, Recoveries: 2, MaxRetries: 3},
+			expectedStatus:     incident.StatusDOWN,
+			expectedRecoveries: 0,
+		},
+		{
+			name:               "pending before an outage, success -> up without recovery",
+			isCurrentUp:        true,
+			monitor:            models.Monitor{IsUp: boolPtr(false), Status: incident.StatusPENDING, RecoveryThreshold: 3, Retries: 1, MaxRetries: 3},
+			expectedStatus:     incident.StatusUP,
+			expectedRetries:    1,
+			expectedRecoveries: 0,
+		},
+		{
+			name:               "pending during an outage, success -> recovering",
+			isCurrentUp:        true,
+			monitor:            models.Monitor{IsUp: boolPtr(false), Status: incident.StatusPENDING, Down: true, RecoveryThreshold: 3, Retries: 1, MaxRetries: 3},
+			expectedStatus:     incident.StatusRECOVERING,
+			expectedRetries:    1,
+			expectedRecoveries: 1,
+		},
+		{
+			name:               "maintenance during an outage, success -> recovering",
+			isCurrentUp:        true,
+			monitor:            models.Monitor{IsUp: boolPtr(true), Status: incident.StatusMAINTENANCE, Down: true, RecoveryThreshold: 3},
+			expectedStatus:     incident.StatusRECOVERING,
+			expectedRecoveries: 1,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -147,6 +228,7 @@ func TestDetermineStatus(t *testing.T) {
 			status := determineStatus(tc.isCurrentUp, &tc.monitor)
 			assert.Equal(t, tc.expectedStatus, status)
 			assert.Equal(t, tc.expectedRetries, tc.monitor.Retries)
+			assert.Equal(t, tc.expectedRecoveries, tc.monitor.Recoveries)
 		})
 	}
 }
@@ -191,12 +273,184 @@ func TestMonitorResolveIncidents(t *testing.T) {
 				tc.setup(db, &tc.monitor)
 			}
 
-			result := uptimeMonitor.resolveIncidents(&tc.monitor, tc.incidentType)
+			result := uptimeMonitor.resolveIncidents(&tc.monitor, &net.CheckResults{}, tc.incidentType)
 			assert.Equal(t, tc.expectedResult, result)
 		})
 	}
 }
 
+func TestMonitorFollowUp(t *testing.T) {
+	ago := func(d time.Duration) *time.Time {
+		at := time.Now().Add(-d)
+		return &at
+	}
+
+	testCases := []struct {
+		name             string
+		monitor          models.Monitor
+		incident         models.Incident
+		expectedResult   bool
+		expectedSeverity incident.Severity
+		expectedCount    int
+	}{
+		{
+			name:             "disabled",
+			monitor:          models.Monitor{},
+			incident:         models.Incident{CreatedAt: *ago(24 * time.Hour), Severity: incident.HIGH},
+			expectedResult:   false,
+			expectedSeverity: incident.HIGH,
+		},
+		{
+			name:             "reminder due",
+			monitor:          models.Monitor{ReminderInterval: 30 * time.Minute},
+			incident:         models.Incident{CreatedAt: *ago(2 * time.Hour), LastNotifiedAt: ago(31 * time.Minute), Reminders: 2, Severity: incident.HIGH},
+			expectedResult:   true,
+			expectedSeverity: incident.HIGH,
+			expectedCount:    3,
+		},
+		{
+			name:             "reminder not due",
+			monitor:          models.Monitor{ReminderInterval: 30 * time.Minute},
+			incident:         models.Incident{CreatedAt: *ago(2 * time.Hour), LastNotifiedAt: ago(10 * time.Minute), Reminders: 2, Severity: incident.HIGH},
+			expectedResult:   false,
+			expectedSeverity: incident.HIGH,
+			expectedCount:    2,
+		},
+		{
+			name:             "escalation due",
+			monitor:          models.Monitor{EscalateAfter: time.Hour, EscalateTo: incident.CRITICAL},
+			incident:         models.Incident{CreatedAt: *ago(61 * time.Minute), Severity: incident.HIGH},
+			expectedResult:   true,
+			expectedSeverity: incident.CRITICAL,
+			expectedCount:    0,
+		},
+		{
+			name:             "escalation and reminder due",
+			monitor:          models.Monitor{ReminderInterval: 30 * time.Minute, EscalateAfter: time.Hour, EscalateTo: incident.CRITICAL},
+			incident:         models.Incident{CreatedAt: *ago(61 * time.Minute), LastNotifiedAt: ago(31 * time.Minute), Reminders: 1, Severity: incident.HIGH},
+			expectedResult:   true,
+			expectedSeverity: incident.CRITICAL,
+			expectedCount:    2,
+		},
+		{
+			name:             "already escalated",
+			monitor:          models.Monitor{EscalateAfter: time.Hour, EscalateTo: incident.CRITICAL},
+			incident:         models.Incident{CreatedAt: *ago(3 * time.Hour), LastNotifiedAt: ago(2 * time.Hour), Reminders: 1, Severity: incident.CRITICAL},
+			expectedResult:   false,
+			expectedSeverity: incident.CRITICAL,
+			expectedCount:    1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, _ := database.InitializeTestDatabase()
+			uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+
+			tc.monitor.ID = "monitor"
+			tc.monitor.URL = "https://example.com"
+			tc.monitor.Incidents = []models.Incident{tc.incident}
+			tc.monitor.Incidents[0].ID = "incident"
+			tc.monitor.Incidents[0].Type = incident.Timeout
+			db.DB.Create(&tc.monitor)
+
+			inc := db.GetLastIncident(tc.monitor.URL, incident.Timeout)
+			result := uptimeMonitor.followUp(&tc.monitor, &net.CheckResults{}, inc, incident.EventWebsiteDown, map[string]any{})
+			assert.Equal(t, tc.expectedResult, result)
+
+			// The follow-up state survives a restart
+			stored := db.GetLastIncident(tc.monitor.URL, incident.Timeout)
+			assert.Equal(t, tc.expectedSeverity, stored.Severity)
+			assert.Equal(t, tc.expectedCount, stored.Reminders)
+		})
+	}
+}
+
+// blockingNotifier records the notifications it gets once released
+type blockingNotifier struct {
+	release  chan struct{}
+	statuses []string
+	severity []incident.Severity
+	resolved []bool
+}
+
+func (b *blockingNotifier) Name() string { return "blocking" }
+
+func (b *blockingNotifier) Notify(n *net.Notification) error {
+	<-b.release
+	b.statuses = append(b.statuses, n.Monitor.Status)
+	b.severity = append(b.severity, n.Incident.Severity)
+	b.resolved = append(b.resolved, n.Resolved)
+	return nil
+}
+
+func TestNotifyDispatchesCopies(t *testing.T) {
+	notifier := &blockingNotifier{release: make(chan struct{})}
+	net.RegisterNotifier("blocking", func(configuration.ChannelConfig) (net.Notifier, error) {
+		return notifier, nil
+	})
+
+	db, _ := database.InitializeTestDatabase()
+	uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+	uptimeMonitor.dispatcher = net.NewDispatcher(configuration.NotificationsConfig{
+		Channels: []configuration.ChannelConfig{{Name: "blocking", Type: "blocking"}},
+	})
+
+	monitor := &models.Monitor{ID: "monitor", URL: "https://example.com", Status: incident.StatusDOWN}
+	inc := &models.Incident{ID: "incident", Type: incident.Timeout, Severity: incident.HIGH}
+	uptimeMonitor.notify(monitor, &net.CheckResults{}, inc, incident.EventWebsiteDown, map[string]any{})
+
+	// The next check updates the live monitor and incident while the channel renders
+	monitor.Status = incident.StatusUP
+	inc.Severity = incident.CRITICAL
+	close(notifier.release)
+	uptimeMonitor.dispatcher.Wait()
+
+	assert.Equal(t, []string{incident.StatusDOWN}, notifier.statuses)
+	assert.Equal(t, []incident.Severity{incident.HIGH}, notifier.severity)
+}
+
+func TestFlapRate(t *testing.T) {
+	assert.Equal(t, 0.0, flapRate(nil))
+	assert.Equal(t, 0.0, flapRate([]bool{true, true, true}))
+	assert.Equal(t, 100.0, flapRate([]bool{true, false, true, false, true}))
+	assert.Equal(t, 50.0, flapRate([]bool{true, true, false, false, true}))
+}
+
+func TestMonitorHandleFlapping(t *testing.T) {
+	db, _ := database.InitializeTestDatabase()
+	uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+
+	monitor := &models.Monitor{ID: "flappy", URL: "https://example.com", FlapWindow: 5, FlapThreshold: 50}
+	db.DB.Create(monitor)
+
+	checkedAt := time.Now().Add(-time.Hour)
+	addHistories := func(states ...bool) {
+		for _, isUp := range states {
+			checkedAt = checkedAt.Add(time.Minute)
+			db.DB.Create(&models.MonitorHistory{MonitorID: monitor.ID, IsUp: isUp, CreatedAt: checkedAt})
+		}
+	}
+
+	// Not enough checks yet
+	addHistories(true, false)
+	assert.False(t, uptimeMonitor.handleFlapping(monitor, &net.CheckResults{IsUp: true}))
+
+	// Oscillating, one flapping incident is opened
+	addHistories(true, false, true, false)
+	assert.True(t, uptimeMonitor.handleFlapping(monitor, &net.CheckResults{IsUp: true}))
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.Flapping).IsExists())
+
+	// Still above half the threshold, the monitor keeps flapping
+	addHistories(true, true, false)
+	assert.True(t, uptimeMonitor.handleFlapping(monitor, &net.CheckResults{IsUp: true}))
+
+	// Stable again, the incident is resolved
+	addHistories(true, true, true, true)
+	assert.False(t, uptimeMonitor.handleFlapping(monitor, &net.CheckResults{IsUp: true}))
+	assert.False(t, db.GetLastIncident(monitor.URL, incident.Flapping).IsExists())
+}
+
 func TestHandleSSL(t *testing.T) {
 	expiredDuration := time.Hour * 24 * 30 // 30 days
 	now := time.Now()
@@ -307,6 +561,201 @@ func TestHandleSSL(t *testing.T) {
 	}
 }
 
+func TestHandleSSLResolvesOnChannels(t *testing.T) {
+	notifier := &blockingNotifier{release: make(chan struct{})}
+	close(notifier.release)
+	net.RegisterNotifier("blocking", func(configuration.ChannelConfig) (net.Notifier, error) {
+		return notifier, nil
+	})
+
+	db, _ := database.InitializeTestDatabase()
+	uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+	uptimeMonitor.dispatcher = net.NewDispatcher(configuration.NotificationsConfig{
+		Channels: []configuration.ChannelConfig{{Name: "blocking", Type: "blocking"}},
+	})
+
+	monitor := &models.Monitor{
+		ID:        "monitor",
+		URL:       "https://example.com",
+		Incidents: []models.Incident{{ID: "incident", Description: "Certificate expired", Type: incident.SSLExpired}},
+	}
+	db.DB.Create(monitor)
+
+	renewed := time.Now().Add(90 * 24 * time.Hour)
+	assert.True(t, uptimeMonitor.handleSSL(monitor, &net.CheckResults{SSLExpiredDate: &renewed}))
+	uptimeMonitor.dispatcher.Wait()
+
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.SSLExpired).IsNotExists())
+	assert.Equal(t, []bool{true}, notifier.resolved, "channels should get the resolution")
+}
+
+func TestHandleCertificate(t *testing.T) {
+	db, _ := database.InitializeTestDatabase()
+	uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+
+	monitor := &models.Monitor{ID: "tls", URL: "https://example.com", CertificateMonitoring: true}
+	db.DB.Create(monitor)
+
+	problems := &models.CertificateInfo{
+		Verified:             true,
+		SelfSigned:           true,
+		KeyType:              "RSA",
+		KeySize:              1024,
+		WeakKey:              true,
+		ExpiredIntermediates: []string{"CN=Intermediate"},
+	}
+	uptimeMonitor.handleCertificate(monitor, &net.CheckResults{Certificate: problems})
+
+	for _, incidentType := range []incident.Type{
+		incident.CertificateHostnameMismatch,
+		incident.CertificateUntrusted,
+		incident.CertificateWeakKey,
+		incident.CertificateIntermediateExpired,
+	} {
+		assert.True(t, db.GetLastIncident(monitor.URL, incidentType).IsExists(), incidentType)
+	}
+	assert.Equal(t, "Certificate is self-signed", db.GetLastIncident(monitor.URL, incident.CertificateUntrusted).Description)
+	assert.Equal(t, incident.MEDIUM, db.GetLastIncident(monitor.URL, incident.CertificateWeakKey).Severity)
+
+	// Unverified connections leave the incidents untouched
+	uptimeMonitor.handleCertificate(monitor, &net.CheckResults{Certificate: &models.CertificateInfo{}})
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.CertificateUntrusted).IsExists())
+
+	valid := &models.CertificateInfo{Verified: true, ChainTrusted: true, HostnameValid: true}
+	uptimeMonitor.handleCertificate(monitor, &net.CheckResults{Certificate: valid})
+
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.CertificateHostnameMismatch).IsNotExists())
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.CertificateUntrusted).IsNotExists())
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.CertificateWeakKey).IsNotExists())
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.CertificateIntermediateExpired).IsNotExists())
+}
+
+func TestHandlePartialOutage(t *testing.T) {
+	db, _ := database.InitializeTestDatabase()
+	uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+
+	monitor := &models.Monitor{ID: "pool", URL: "https://pool.example.com", AllAddresses: true, MaxRetries: 1}
+	db.DB.Create(monitor)
+
+	check := func(addresses ...models.AddressResult) *net.CheckResults {
+		result := &net.CheckResults{IsUp: true, Addresses: addresses}
+		countAddressFailures(monitor.Addresses, result.Addresses)
+		monitor.Addresses = result.Addresses
+		return result
+	}
+
+	partial := check(
+		models.AddressResult{IP: "192.0.2.10", IsUp: true},
+		models.AddressResult{IP: "192.0.2.11", Error: "connection refused"},
+	)
+	assert.Equal(t, []string{"192.0.2.11"}, failedAddresses(partial))
+
+	// A single failed probe is retried before an incident is opened
+	assert.False(t, uptimeMonitor.handlePartialOutage(monitor, partial))
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.PartialOutage).IsNotExists())
+
+	partial = check(
+		models.AddressResult{IP: "192.0.2.10", IsUp: true},
+		models.AddressResult{IP: "192.0.2.11", Error: "connection refused"},
+		models.AddressResult{IP: "192.0.2.12", Error: "timeout"},
+	)
+	assert.Equal(t, 2, partial.Addresses[1].Failures)
+	assert.Equal(t, 1, partial.Addresses[2].Failures)
+
+	assert.True(t, uptimeMonitor.handlePartialOutage(monitor, partial))
+	inc := db.GetLastIncident(monitor.URL, incident.PartialOutage)
+	assert.True(t, inc.IsExists())
+	assert.Equal(t, incident.HIGH, inc.Severity)
+	assert.Equal(t, "2 of 3 addresses are down: 192.0.2.11, 192.0.2.12", inc.Description)
+
+	// Still partially down - no duplicate incident
+	assert.False(t, uptimeMonitor.handlePartialOutage(monitor, partial))
+
+	healthy := check(
+		models.AddressResult{IP: "192.0.2.10", IsUp: true},
+		models.AddressResult{IP: "192.0.2.11", IsUp: true},
+		models.AddressResult{IP: "192.0.2.12", IsUp: true},
+	)
+	assert.False(t, uptimeMonitor.handlePartialOutage(monitor, healthy))
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.PartialOutage).IsNotExists())
+}
+
+func TestHandleFamilies(t *testing.T) {
+	db, _ := database.InitializeTestDatabase()
+	uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+
+	monitor := &models.Monitor{ID: "dual", URL: "https://dual.example.com", IPType: "both", MaxRetries: 1}
+	db.DB.Create(monitor)
+
+	check := func(families ...models.FamilyResult) *net.CheckResults {
+		result := &net.CheckResults{IsUp: true, Families: families}
+		countFamilyFailures(monitor.Families, result.Families)
+		monitor.Families = result.Families
+		return result
+	}
+	v6Down := func() *net.CheckResults {
+		return check(
+			models.FamilyResult{Family: "ipv4", IsUp: true},
+			models.FamilyResult{Family: "ipv6", Error: "no IPv6 addresses found for host: dual.example.com"},
+		)
+	}
+
+	result := v6Down()
+	assert.Equal(t, []string{"ipv6"}, partialFailures(result))
+
+	// A single failed probe is retried before an incident is opened
+	uptimeMonitor.handleFamilies(monitor, result)
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.IPv6Unreachable).IsNotExists())
+
+	uptimeMonitor.handleFamilies(monitor, v6Down())
+	inc := db.GetLastIncident(monitor.URL, incident.IPv6Unreachable)
+	assert.True(t, inc.IsExists())
+	assert.Equal(t, "Unreachable over ipv6: no IPv6 addresses found for host: dual.example.com", inc.Description)
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.IPv4Unreachable).IsNotExists())
+
+	// Still down - no duplicate incident
+	uptimeMonitor.handleFamilies(monitor, v6Down())
+	var count int64
+	db.DB.Model(&models.Incident{}).Where("type = ?", incident.IPv6Unreachable).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	v4Down := func() *net.CheckResults {
+		return check(
+			models.FamilyResult{Family: "ipv4", Error: "connection refused"},
+			models.FamilyResult{Family: "ipv6", IsUp: true},
+		)
+	}
+	uptimeMonitor.handleFamilies(monitor, v4Down())
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.IPv6Unreachable).IsNotExists())
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.IPv4Unreachable).IsNotExists())
+
+	uptimeMonitor.handleFamilies(monitor, v4Down())
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.IPv4Unreachable).IsExists())
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.IPv6Unreachable).IsNotExists())
+
+	// Checking a single family again resolves the incidents
+	uptimeMonitor.handleFamilies(monitor, &net.CheckResults{IsUp: true})
+	assert.True(t, db.GetLastIncident(monitor.URL, incident.IPv4Unreachable).IsNotExists())
+}
+
+// scriptedChecker returns the scripted results in order, failed results
+// with err
+type scriptedChecker struct {
+	results []*net.CheckResults
+	err     error
+	calls   int
+}
+
+func (c *scriptedChecker) Check(*net.NetworkConfig) (*net.CheckResults, error) {
+	result := c.results[c.calls]
+	c.calls++
+	result.LastCheck = time.Now()
+	if result.IsUp {
+		return result, nil
+	}
+	return result, c.err
+}
+
 func TestCheckWebsite(t *testing.T) {
 	boolPtr := func(v bool) *bool {
 		return &v
@@ -364,6 +813,112 @@ func TestCheckWebsite(t *testing.T) {
 		assert.Equal(t, "Received non-successful status code: 500 Internal Server Error", lastIncident.Description)
 	})
 
+	t.Run("website degraded then recovered", func(t *testing.T) {
+		delay := 50 * time.Millisecond
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(delay)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		db, _ := database.InitializeTestDatabase()
+		uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+		monitor := &models.Monitor{
+			URL:                   server.URL,
+			Interval:              1 * time.Minute,
+			ResponseTimeThreshold: 5 * time.Second,
+			DegradedThreshold:     20 * time.Millisecond,
+		}
+		db.DB.Create(monitor)
+
+		uptimeMonitor.checkWebsite(monitor)
+
+		db.DB.First(monitor)
+		assert.True(t, *monitor.IsUp)
+		assert.Equal(t, incident.StatusDEGRADED, monitor.Status)
+		assert.True(t, uptimeMonitor.db.GetLastIncident(monitor.URL, incident.SlowResponse).IsExists())
+
+		delay = 0
+		uptimeMonitor.checkWebsite(monitor)
+
+		db.DB.First(monitor)
+		assert.Equal(t, incident.StatusUP, monitor.Status)
+		assert.True(t, uptimeMonitor.db.GetLastIncident(monitor.URL, incident.SlowResponse).IsNotExists())
+	})
+
+	t.Run("recovery confirmed after a retry phase", func(t *testing.T) {
+		var failing atomic.Bool
+		failing.Store(true)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		db, _ := database.InitializeTestDatabase()
+		uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+		monitor := &models.Monitor{
+			ID:                    "recovery",
+			URL:                   server.URL,
+			Interval:              1 * time.Minute,
+			ResponseTimeThreshold: 5 * time.Second,
+			MaxRetries:            1,
+			RetryInterval:         1 * time.Second,
+			RecoveryThreshold:     2,
+			IsUp:                  boolPtr(true),
+		}
+		db.DB.Create(monitor)
+
+		for _, expected := range []string{incident.StatusPENDING, incident.StatusDOWN, incident.StatusPENDING} {
+			uptimeMonitor.checkWebsite(monitor)
+			assert.Equal(t, expected, monitor.Status)
+		}
+
+		failing.Store(false)
+		uptimeMonitor.checkWebsite(monitor)
+		assert.Equal(t, incident.StatusRECOVERING, monitor.Status)
+		assert.True(t, uptimeMonitor.db.GetLastIncident(monitor.URL, incident.UnexpectedStatusCode).IsExists())
+
+		uptimeMonitor.checkWebsite(monitor)
+		assert.Equal(t, incident.StatusUP, monitor.Status)
+		assert.False(t, monitor.Down)
+		assert.True(t, uptimeMonitor.db.GetLastIncident(monitor.URL, incident.UnexpectedStatusCode).IsNotExists())
+	})
+
+	t.Run("dns mismatch after retries keeps the previous answers", func(t *testing.T) {
+		checker := &scriptedChecker{results: []*net.CheckResults{
+			{IsUp: true, Answers: []string{"192.0.2.10"}},
+			{Answers: []string{"198.51.100.1"}, ErrorMessage: "dns answer mismatch"},
+			{Answers: []string{"198.51.100.1"}, ErrorMessage: "dns answer mismatch"},
+		}, err: net.ErrDNSMismatch}
+		net.RegisterChecker("scripted-dns", checker)
+
+		db, _ := database.InitializeTestDatabase()
+		uptimeMonitor, _ := NewUptimeMonitor(db, nil)
+		monitor := &models.Monitor{
+			ID:          "dns",
+			URL:         "dns://example.com",
+			Type:        "scripted-dns",
+			Interval:    1 * time.Minute,
+			MaxRetries:  1,
+			IsUp:        boolPtr(true),
+			DNSExpected: []string{"192.0.2.10"},
+		}
+		db.DB.Create(monitor)
+
+		for range checker.results {
+			uptimeMonitor.checkWebsite(monitor)
+		}
+		assert.Equal(t, incident.StatusDOWN, monitor.Status)
+		assert.Equal(t, []string{"192.0.2.10"}, monitor.DNSAnswers)
+
+		lastIncident := uptimeMonitor.db.GetLastIncident(monitor.URL, incident.DNSMismatch)
+		assert.True(t, lastIncident.IsExists())
+	})
+
 	t.Run("website pending with retries", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
@@ -392,3 +947,46 @@ func TestCheckWebsite(t *testing.T) {
 		assert.True(t, lastIncident.IsNotExists())
 	})
 }
+
+func TestMonitorReload(t *testing.T) {
+	db, _ := database.InitializeTestDatabase()
+
+	unchanged := &models.Monitor{URL: "https://unchanged.example.com", Enabled: true, Interval: time.Minute}
+	changed := &models.Monitor{URL: "https://changed.example.com", Enabled: true, Interval: time.Minute}
+	removed := &models.Monitor{URL: "https://removed.example.com", Enabled: true, Interval: time.Minute}
+
+	uptimeMonitor, _ := NewUptimeMonitor(db, []*models.Monitor{unchanged, changed, removed})
+	for _, cfg := range uptimeMonitor.configs {
+		uptimeMonitor.scheduler.Add(cfg)
+	}
+
+	// State fields must not count as a configuration change
+	unchanged.Status = incident.StatusDOWN
+	changed.Status = incident.StatusDOWN
+
+	updated := &models.Monitor{URL: changed.URL, Enabled: true, Interval: 2 * time.Minute}
+	added := &models.Monitor{URL: "https://added.example.com", Enabled: true, Interval: time.Minute}
+	disabled := &models.Monitor{URL: "https://disabled.example.com", Enabled: false, Interval: time.Minute}
+
+	uptimeMonitor.Reload([]*models.Monitor{
+		{URL: unchanged.URL, Enabled: true, Interval: time.Minute},
+		updated,
+		added,
+		disabled,
+	})
+
+	assert.Len(t, uptimeMonitor.configs, 4)
+	assert.Same(t, unchanged, uptimeMonitor.configs[0], "unchanged monitor should keep running")
+	assert.Same(t, changed, uptimeMonitor.configs[1], "changed monitor should keep its state")
+	assert.Equal(t, 2*time.Minute, changed.Interval)
+	assert.Equal(t, incident.StatusDOWN, changed.Status)
+	assert.Same(t, added, uptimeMonitor.configs[2])
+
+	assert.Equal(t, 3, uptimeMonitor.scheduler.Len())
+	assert.Contains(t, uptimeMonitor.scheduler.entries, unchanged)
+	assert.Contains(t, uptimeMonitor.scheduler.entries, changed)
+	assert.Contains(t, uptimeMonitor.scheduler.entries, added)
+	assert.NotContains(t, uptimeMonitor.scheduler.entries, updated)
+	assert.NotContains(t, uptimeMonitor.scheduler.entries, removed)
+	assert.NotContains(t, uptimeMonitor.scheduler.entries, disabled)
+}
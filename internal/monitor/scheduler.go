@@ -0,0 +1,298 @@
+package monitor
+
+import (
+	"container/heap"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"uptime-go/internal/models"
+)
+
+// DefaultMaxConcurrentChecks is the worker pool size used when none is configured
+const DefaultMaxConcurrentChecks = 100
+
+// SchedulerConfig controls how many checks run at once and how much the
+// first check of each monitor is delayed to avoid a stampede at boot.
+type SchedulerConfig struct {
+	MaxConcurrentChecks int
+	StartJitter         time.Duration
+}
+
+// Scheduler keeps every monitor in a min-heap ordered by next run time and
+// feeds due monitors to a bounded pool of workers. A monitor is never run
+// concurrently with itself; it is rescheduled once its check completes.
+type Scheduler struct {
+	run     func(*models.Monitor)
+	workers int
+	jitter  time.Duration
+
+	mutex   sync.Mutex
+	idle    *sync.Cond // signaled when a check completes
+	queue   scheduleQueue
+	entries map[*models.Monitor]*scheduleEntry
+	running bool
+
+	wake     chan struct{}
+	jobs     chan *scheduleEntry
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+type scheduleEntry struct {
+	monitor *models.Monitor
+	next    time.Time
+	index   int // position in the heap, -1 while running or removed
+	running bool
+	removed bool
+}
+
+func NewScheduler(cfg SchedulerConfig, run func(*models.Monitor)) *Scheduler {
+	workers := cfg.MaxConcurrentChecks
+	if workers <= 0 {
+		workers = DefaultMaxConcurrentChecks
+	}
+
+	s := &Scheduler{
+		run:      run,
+		workers:  workers,
+		jitter:   cfg.StartJitter,
+		entries:  make(map[*models.Monitor]*scheduleEntry),
+		wake:     make(chan struct{}, 1),
+		jobs:     make(chan *scheduleEntry),
+		stopChan: make(chan struct{}),
+	}
+	s.idle = sync.NewCond(&s.mutex)
+
+	return s
+}
+
+// Start launches the scheduling loop and the worker pool
+func (s *Scheduler) Start() {
+	s.mutex.Lock()
+	if s.running {
+		s.mutex.Unlock()
+		return
+	}
+	s.running = true
+	s.mutex.Unlock()
+
+	s.wg.Add(s.workers + 1)
+	go s.loop()
+	for i := 0; i < s.workers; i++ {
+		go s.worker()
+	}
+}
+
+// Stop stops scheduling new checks and waits for running checks to finish
+func (s *Scheduler) Stop() {
+	close(s.stopChan)
+	s.wg.Wait()
+}
+
+// Add schedules the first check of a monitor after a random start jitter
+func (s *Scheduler) Add(monitor *models.Monitor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.entries[monitor]; ok {
+		return
+	}
+
+	entry := &scheduleEntry{
+		monitor: monitor,
+		next:    time.Now().Add(s.startDelay(monitor)),
+	}
+	s.entries[monitor] = entry
+	heap.Push(&s.queue, entry)
+	s.notify()
+}
+
+// Remove unschedules a monitor. A check already in progress completes before
+// Remove returns, so its results are saved before a replacing monitor runs,
+// and the monitor is not rescheduled afterwards.
+func (s *Scheduler) Remove(monitor *models.Monitor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.entries[monitor]
+	if !ok {
+		return
+	}
+
+	entry.removed = true
+	if entry.index >= 0 {
+		heap.Remove(&s.queue, entry.index)
+	}
+	delete(s.entries, monitor)
+	s.notify()
+
+	for entry.running {
+		s.idle.Wait()
+	}
+}
+
+// Len returns the number of scheduled monitors
+func (s *Scheduler) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.entries)
+}
+
+func (s *Scheduler) loop() {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		due, wait := s.popDue(time.Now())
+
+		for _, entry := range due {
+			select {
+			case s.jobs <- entry:
+			case <-s.stopChan:
+				return
+			}
+		}
+
+		if len(due) > 0 {
+			// Sending may have blocked on busy workers, re-evaluate the heap
+			continue
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-s.wake:
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// popDue removes every entry due at now from the heap and returns them
+// together with the delay until the next entry is due.
+func (s *Scheduler) popDue(now time.Time) ([]*scheduleEntry, time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var due []*scheduleEntry
+	for s.queue.Len() > 0 && !s.queue[0].next.After(now) {
+		due = append(due, heap.Pop(&s.queue).(*scheduleEntry))
+	}
+
+	wait := time.Hour
+	if s.queue.Len() > 0 {
+		wait = s.queue[0].next.Sub(now)
+	}
+
+	return due, wait
+}
+
+func (s *Scheduler) worker() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case entry := <-s.jobs:
+			if !s.claim(entry) {
+				continue
+			}
+			s.run(entry.monitor)
+			s.reschedule(entry)
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// claim marks the entry as running unless it was removed in the meantime
+func (s *Scheduler) claim(entry *scheduleEntry) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if entry.removed {
+		return false
+	}
+	entry.running = true
+	return true
+}
+
+func (s *Scheduler) reschedule(entry *scheduleEntry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry.running = false
+	s.idle.Broadcast()
+
+	if entry.removed {
+		return
+	}
+
+	entry.next = time.Now().Add(nextInterval(entry.monitor))
+	heap.Push(&s.queue, entry)
+	s.notify()
+}
+
+// notify wakes the scheduling loop so it can pick up heap changes
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) startDelay(monitor *models.Monitor) time.Duration {
+	jitter := s.jitter
+	if interval := nextInterval(monitor); interval < jitter {
+		jitter = interval
+	}
+	if jitter <= 0 {
+		return 0
+	}
+
+	return rand.N(jitter)
+}
+
+// nextInterval returns the delay before the next check of a monitor,
+// using the faster retry interval while the monitor is PENDING or RECOVERING.
+func nextInterval(monitor *models.Monitor) time.Duration {
+	if (monitor.Retries > 0 || monitor.Recoveries > 0) && monitor.RetryInterval > 0 {
+		return monitor.RetryInterval
+	}
+	if monitor.Interval > 0 {
+		return monitor.Interval
+	}
+	return time.Minute
+}
+
+// scheduleQueue is a min-heap of schedule entries ordered by next run time
+type scheduleQueue []*scheduleEntry
+
+func (q scheduleQueue) Len() int { return len(q) }
+
+func (q scheduleQueue) Less(i, j int) bool { return q[i].next.Before(q[j].next) }
+
+func (q scheduleQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *scheduleQueue) Push(x any) {
+	entry := x.(*scheduleEntry)
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *scheduleQueue) Pop() any {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*q = old[:n-1]
+	return entry
+}
@@ -0,0 +1,113 @@
+package monitor
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"uptime-go/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerBoundsConcurrency(t *testing.T) {
+	var running, maxRunning, runs atomic.Int32
+
+	scheduler := NewScheduler(SchedulerConfig{MaxConcurrentChecks: 3}, func(monitor *models.Monitor) {
+		current := running.Add(1)
+		for {
+			previous := maxRunning.Load()
+			if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		runs.Add(1)
+	})
+
+	for i := 0; i < 12; i++ {
+		scheduler.Add(&models.Monitor{Interval: time.Hour})
+	}
+
+	scheduler.Start()
+	assert.Eventually(t, func() bool { return runs.Load() == 12 }, 2*time.Second, 10*time.Millisecond)
+	scheduler.Stop()
+
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+	assert.Equal(t, int32(12), runs.Load(), "each monitor should run once per interval")
+}
+
+func TestSchedulerReschedulesAndRemoves(t *testing.T) {
+	var mutex sync.Mutex
+	counts := make(map[*models.Monitor]int)
+
+	scheduler := NewScheduler(SchedulerConfig{MaxConcurrentChecks: 2}, func(monitor *models.Monitor) {
+		mutex.Lock()
+		counts[monitor]++
+		mutex.Unlock()
+	})
+
+	kept := &models.Monitor{Interval: 20 * time.Millisecond}
+	removed := &models.Monitor{Interval: 20 * time.Millisecond}
+	scheduler.Add(kept)
+	scheduler.Add(removed)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	count := func(monitor *models.Monitor) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return counts[monitor]
+	}
+
+	assert.Eventually(t, func() bool { return count(removed) >= 2 }, 2*time.Second, 5*time.Millisecond)
+	scheduler.Remove(removed)
+	assert.Equal(t, 1, scheduler.Len())
+
+	removedRuns := count(removed)
+	keptRuns := count(kept)
+	assert.Eventually(t, func() bool { return count(kept) >= keptRuns+3 }, 2*time.Second, 5*time.Millisecond)
+	assert.LessOrEqual(t, count(removed), removedRuns+1, "removed monitor should not be rescheduled")
+}
+
+func TestSchedulerRemoveWaitsForRunningCheck(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var finished atomic.Bool
+
+	scheduler := NewScheduler(SchedulerConfig{MaxConcurrentChecks: 1}, func(monitor *models.Monitor) {
+		close(started)
+		<-release
+		finished.Store(true)
+	})
+
+	monitor := &models.Monitor{Interval: time.Hour}
+	scheduler.Add(monitor)
+	scheduler.Start()
+	defer scheduler.Stop()
+	<-started
+
+	removed := make(chan struct{})
+	go func() {
+		scheduler.Remove(monitor)
+		close(removed)
+	}()
+
+	select {
+	case <-removed:
+		t.Fatal("Remove returned while the check was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-removed
+	assert.True(t, finished.Load(), "check should complete before Remove returns")
+	assert.Equal(t, 0, scheduler.Len())
+}
+
+func TestNextInterval(t *testing.T) {
+	assert.Equal(t, time.Minute, nextInterval(&models.Monitor{Interval: time.Minute, RetryInterval: time.Second}))
+	assert.Equal(t, time.Second, nextInterval(&models.Monitor{Interval: time.Minute, RetryInterval: time.Second, Retries: 1}))
+	assert.Equal(t, time.Second, nextInterval(&models.Monitor{Interval: time.Minute, RetryInterval: time.Second, Recoveries: 1}))
+}
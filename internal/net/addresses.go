@@ -0,0 +1,74 @@
+package net
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+	"uptime-go/internal/models"
+)
+
+// checkAllAddresses resolves the host once and checks every address on its
+// own, concurrently. The returned result is the one of the first address
+// that is up, or of the first address when all of them are down, with the
+// result of every address listed in Addresses.
+func (nc *NetworkConfig) checkAllAddresses() (*CheckResults, error) {
+	target, err := url.Parse(nc.URL)
+	if err != nil || net.ParseIP(target.Hostname()) != nil {
+		return nc.checkWebsite()
+	}
+	host := target.Hostname()
+
+	dnsStart := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), durationOrDefault(nc.DNSTimeout, 5*time.Second))
+	ips, err := nc.lookupIP(ctx, host)
+	cancel()
+	if err != nil {
+		// The regular check reports the resolution error
+		return nc.checkWebsite()
+	}
+	dnsTime := time.Since(dnsStart)
+
+	results := make([]*CheckResults, len(ips))
+	errs := make([]error, len(ips))
+
+	var wg sync.WaitGroup
+	for i, ip := range ips {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			pinned := *nc
+			pinned.pinnedHost, pinned.pinnedIP = host, ip
+			results[i], errs[i] = pinned.checkWebsite()
+		}()
+	}
+	wg.Wait()
+
+	selected := 0
+	for i, result := range results {
+		if result.IsUp {
+			selected = i
+			break
+		}
+	}
+
+	addresses := make([]models.AddressResult, len(results))
+	for i, addressResult := range results {
+		addresses[i] = models.AddressResult{
+			IP:           ips[i].String(),
+			IsUp:         addressResult.IsUp,
+			StatusCode:   addressResult.StatusCode,
+			ResponseTime: addressResult.ResponseTime.Milliseconds(),
+			Error:        addressResult.ErrorMessage,
+		}
+	}
+
+	result := results[selected]
+	result.Addresses = addresses
+	result.DNSTime = dnsTime
+	result.ResponseTime += dnsTime
+
+	return result, errs[selected]
+}
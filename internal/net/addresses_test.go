@@ -0,0 +1,76 @@
+package net
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckWebsiteAllAddresses(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() { _ = server.Close() })
+
+	// Only the first backend of the pool is listening
+	resolver := startDNSServer(t, map[string][]string{
+		"pool.test": {"127.0.0.2", "127.0.0.1"},
+	})
+	previous := hostResolver
+	hostResolver = newResolver(resolver, time.Second)
+	t.Cleanup(func() { hostResolver = previous })
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	nc := &NetworkConfig{URL: "http://pool.test:" + port + "/", Timeout: 5 * time.Second, AllAddresses: true}
+
+	result, err := nc.CheckWebsite()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsUp || result.StatusCode != http.StatusOK {
+		t.Errorf("expected the result of the reachable address, got %+v", result)
+	}
+	if len(result.Addresses) != 2 {
+		t.Fatalf("expected a result per address, got %+v", result.Addresses)
+	}
+
+	failed, reachable := result.Addresses[0], result.Addresses[1]
+	if failed.IP != "127.0.0.2" || failed.IsUp || !strings.Contains(failed.Error, "connection refused") {
+		t.Errorf("expected 127.0.0.2 to be down, got %+v", failed)
+	}
+	if reachable.IP != "127.0.0.1" || !reachable.IsUp || reachable.StatusCode != http.StatusOK {
+		t.Errorf("expected 127.0.0.1 to be up, got %+v", reachable)
+	}
+
+	t.Run("all addresses down", func(t *testing.T) {
+		_ = server.Close()
+
+		result, err := nc.CheckWebsite()
+		if err == nil || result.IsUp {
+			t.Fatalf("expected the check to fail, got %+v", result)
+		}
+		for _, address := range result.Addresses {
+			if address.IsUp {
+				t.Errorf("expected %s to be down", address.IP)
+			}
+		}
+	})
+
+	t.Run("first address only", func(t *testing.T) {
+		nc.AllAddresses = false
+
+		result, _ := nc.CheckWebsite()
+		if result.Addresses != nil {
+			t.Errorf("expected no address results, got %+v", result.Addresses)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package net
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"uptime-go/internal/helper"
+)
+
+const (
+	// defaultMaxBodySize is the number of body bytes read when assertions are configured
+	defaultMaxBodySize = 64 * 1024
+
+	// maxSnippetSize limits the body excerpt attached to incidents
+	maxSnippetSize = 256
+)
+
+// ErrKeywordMismatch is returned when the response body fails one of the
+// body_contains, body_not_contains or body_regex assertions.
+var ErrKeywordMismatch = errors.New("response body assertion failed")
+
+// hasBodyAssertions reports whether the response body has to be inspected
+func (nc *NetworkConfig) hasBodyAssertions() bool {
+	return len(nc.BodyContains) > 0 || len(nc.BodyNotContains) > 0 || nc.BodyRegex != nil ||
+		len(nc.JSONAssertions) > 0
+}
+
+// bodyLimit returns how many bytes of the response body are read
+func (nc *NetworkConfig) bodyLimit() int64 {
+	if !nc.hasBodyAssertions() {
+		return 1024
+	}
+	if nc.MaxBodySize > 0 {
+		return nc.MaxBodySize
+	}
+	return defaultMaxBodySize
+}
+
+// assertBody evaluates the keyword and regex assertions against body
+func (nc *NetworkConfig) assertBody(body []byte) error {
+	for _, keyword := range nc.BodyContains {
+		if !bytes.Contains(body, []byte(keyword)) {
+			return fmt.Errorf("%w: body does not contain %q", ErrKeywordMismatch, keyword)
+		}
+	}
+
+	for _, keyword := range nc.BodyNotContains {
+		if bytes.Contains(body, []byte(keyword)) {
+			return fmt.Errorf("%w: body contains %q", ErrKeywordMismatch, keyword)
+		}
+	}
+
+	if nc.BodyRegex != nil && !nc.BodyRegex.Match(body) {
+		return fmt.Errorf("%w: body does not match %q", ErrKeywordMismatch, nc.BodyRegex.String())
+	}
+
+	return nil
+}
+
+// assertJSONBody evaluates the JSON assertions and reports every failure
+func (nc *NetworkConfig) assertJSONBody(body []byte) ([]string, error) {
+	if len(nc.JSONAssertions) == 0 {
+		return nil, nil
+	}
+
+	failed := assertJSON(body, nc.JSONAssertions)
+	if len(failed) == 0 {
+		return nil, nil
+	}
+
+	return failed, fmt.Errorf("%w: %s", ErrJSONAssertion, strings.Join(failed, "; "))
+}
+
+// bodySnippet returns a single-line excerpt of body suitable for notifications
+func bodySnippet(body []byte) string {
+	snippet := strings.Join(strings.Fields(string(bytes.ToValidUTF8(body, nil))), " ")
+	return helper.Truncate(snippet, maxSnippetSize)
+}
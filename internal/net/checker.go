@@ -0,0 +1,64 @@
+package net
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Monitor types understood by the built-in checkers
+const (
+	CheckerHTTP = "http"
+	CheckerTCP  = "tcp"
+	CheckerDNS  = "dns"
+)
+
+// Checker performs a single availability check for a monitor.
+// Implementations are registered by monitor type and looked up with GetChecker.
+type Checker interface {
+	Check(nc *NetworkConfig) (*CheckResults, error)
+}
+
+var (
+	checkers   = make(map[string]Checker)
+	checkersMu sync.RWMutex
+)
+
+// RegisterChecker makes a checker available for the given monitor type,
+// replacing any checker previously registered under the same name.
+func RegisterChecker(name string, checker Checker) {
+	checkersMu.Lock()
+	defer checkersMu.Unlock()
+
+	checkers[strings.ToLower(name)] = checker
+}
+
+// GetChecker returns the checker registered for the given monitor type.
+// An empty type resolves to the HTTP checker.
+func GetChecker(name string) (Checker, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = CheckerHTTP
+	}
+
+	checkersMu.RLock()
+	defer checkersMu.RUnlock()
+
+	checker, ok := checkers[name]
+	if !ok {
+		return nil, fmt.Errorf("no checker registered for monitor type %q", name)
+	}
+
+	return checker, nil
+}
+
+// httpChecker performs an HTTP(S) request against the monitor URL
+type httpChecker struct{}
+
+func (httpChecker) Check(nc *NetworkConfig) (*CheckResults, error) {
+	return nc.CheckWebsite()
+}
+
+func init() {
+	RegisterChecker(CheckerHTTP, httpChecker{})
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"sync"
 	"time"
+	"uptime-go/internal/helper"
 	"uptime-go/internal/incident"
 	"uptime-go/internal/models"
 
@@ -64,6 +65,7 @@ func New(dbPath string) (*Database, error) {
 		&models.Monitor{},
 		&models.MonitorHistory{},
 		&models.Incident{},
+		&models.OutboxMessage{},
 	); errMigrate != nil {
 		return nil, fmt.Errorf("failed to migrate database schema: %w", errMigrate)
 	}
@@ -88,6 +90,7 @@ func InitializeTestDatabase() (*Database, error) {
 		&models.Monitor{},
 		&models.MonitorHistory{},
 		&models.Incident{},
+		&models.OutboxMessage{},
 	); err != nil {
 		return nil, fmt.Errorf("failed to migrate database schema: %w", err)
 	}
@@ -151,6 +154,24 @@ func (db *Database) GetMonitorWithHistories(url string, limit int) (*models.Moni
 	return &monitor, nil
 }
 
+// GetRecentHistories returns the last limit checks of a monitor made outside
+// maintenance windows, newest first
+func (db *Database) GetRecentHistories(monitorID string, limit int) ([]models.MonitorHistory, error) {
+	var histories []models.MonitorHistory
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if err := db.DB.
+		Where("monitor_id = ? AND maintenance = ?", monitorID, false).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get histories for monitor %s: %w", monitorID, err)
+	}
+
+	return histories, nil
+}
+
 func (db *Database) GetLastIncident(url string, incidentType incident.Type) *models.Incident {
 	var incident models.Incident
 
@@ -166,3 +187,44 @@ func (db *Database) GetLastIncident(url string, incidentType incident.Type) *mod
 
 	return &incident
 }
+
+// MergeMonitors writes the configured monitors to the database, keeping the
+// stored state of existing monitors, and returns the merged records.
+func (db *Database) MergeMonitors(configs []*models.Monitor) ([]*models.Monitor, error) {
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	urls := make([]string, 0, len(configs))
+	configByURL := make(map[string]*models.Monitor, len(configs))
+
+	for _, cfg := range configs {
+		if cfg.ID == "" {
+			cfg.ID = helper.GenerateRandomID()
+		}
+		urls = append(urls, cfg.URL)
+		configByURL[cfg.URL] = cfg
+	}
+
+	columns := models.MonitorConfigColumns
+	if err := db.UpsertRecord(configs, "url", &columns); err != nil {
+		return nil, fmt.Errorf("failed to merge monitors: %w", err)
+	}
+
+	var monitors []*models.Monitor
+	db.mutex.RLock()
+	err := db.DB.Where("url IN ?", urls).Find(&monitors).Error
+	db.mutex.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load merged monitors: %w", err)
+	}
+
+	// Ensure runtime uses config values while keeping DB state fields.
+	for _, monitor := range monitors {
+		if src, ok := configByURL[monitor.URL]; ok {
+			monitor.ApplyConfig(src)
+		}
+	}
+
+	return monitors, nil
+}
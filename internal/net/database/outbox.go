@@ -0,0 +1,70 @@
+package database
+
+import (
+	"fmt"
+	"uptime-go/internal/models"
+)
+
+// EnqueueOutbox stores a notification to be delivered by the outbox sender
+func (db *Database) EnqueueOutbox(message *models.OutboxMessage) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.DB.Create(message).Error; err != nil {
+		return fmt.Errorf("failed to enqueue outbox message: %w", err)
+	}
+	return nil
+}
+
+// GetOutboxMessages returns pending notifications, oldest first
+func (db *Database) GetOutboxMessages(limit int) ([]models.OutboxMessage, error) {
+	var messages []models.OutboxMessage
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if err := db.DB.Order("id ASC").Limit(limit).Find(&messages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get outbox messages: %w", err)
+	}
+	return messages, nil
+}
+
+// DeleteOutboxMessage removes a delivered or discarded notification
+func (db *Database) DeleteOutboxMessage(id uint64) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.DB.Delete(&models.OutboxMessage{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete outbox message %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetIncident returns the incident with its monitor, or nil when it does not exist
+func (db *Database) GetIncident(id string) (*models.Incident, error) {
+	var incidents []models.Incident
+
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if err := db.DB.Preload("Monitor").Where("id = ?", id).Limit(1).Find(&incidents).Error; err != nil {
+		return nil, fmt.Errorf("failed to get incident %s: %w", id, err)
+	}
+	if len(incidents) == 0 {
+		return nil, nil
+	}
+	return &incidents[0], nil
+}
+
+// SetRemoteIncidentID stores the incident id assigned by the master.
+// The column is read-only for regular upserts so that a monitor saving a
+// stale copy of the incident cannot clear it.
+func (db *Database) SetRemoteIncidentID(id string, remoteID uint64) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.DB.Exec("UPDATE incidents SET incident_id = ? WHERE id = ?", remoteID, id).Error; err != nil {
+		return fmt.Errorf("failed to set remote id of incident %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package net
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/helper"
+)
+
+// Discord rejects embeds exceeding these limits
+const (
+	discordMaxFields     = 25
+	discordMaxFieldValue = 1024
+)
+
+// discordNotifier posts notifications to a Discord webhook
+type discordNotifier struct {
+	name       string
+	webhookURL string
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields,omitempty"`
+	Timestamp   string         `json:"timestamp"`
+}
+
+type discordPayload struct {
+	Username string         `json:"username"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+func init() {
+	RegisterNotifier(ChannelDiscord, func(cfg configuration.ChannelConfig) (Notifier, error) {
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("discord channel %q requires webhook_url", cfg.Name)
+		}
+		return &discordNotifier{name: cfg.Name, webhookURL: cfg.WebhookURL}, nil
+	})
+}
+
+func (d *discordNotifier) Name() string {
+	return d.name
+}
+
+func (d *discordNotifier) Notify(n *Notification) error {
+	var fields []discordField
+	for _, field := range n.Fields() {
+		if len(fields) == discordMaxFields {
+			break
+		}
+		fields = append(fields, discordField{
+			Name:   field.Name,
+			Value:  helper.Truncate(field.Value, discordMaxFieldValue-3),
+			Inline: len(field.Value) <= 40,
+		})
+	}
+
+	color, _ := strconv.ParseInt(n.Color(), 16, 32)
+
+	return postJSON(d.webhookURL, discordPayload{
+		Username: "uptime-go",
+		Embeds: []discordEmbed{{
+			Title:       n.Title(),
+			Description: n.Text(),
+			Color:       int(color),
+			Fields:      fields,
+			Timestamp:   n.Time.UTC().Format(time.RFC3339),
+		}},
+	})
+}
@@ -0,0 +1,218 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DNS record types supported by the dns checker
+const (
+	RecordA     = "A"
+	RecordAAAA  = "AAAA"
+	RecordCNAME = "CNAME"
+	RecordMX    = "MX"
+	RecordTXT   = "TXT"
+	RecordNS    = "NS"
+)
+
+// ErrDNSMismatch is returned when the resolved answers do not match the
+// expected answer list or pattern.
+var ErrDNSMismatch = errors.New("dns answer mismatch")
+
+// dnsChecker queries a record of the monitored host and asserts its answers
+type dnsChecker struct{}
+
+func (dnsChecker) Check(nc *NetworkConfig) (*CheckResults, error) {
+	return nc.CheckDNS()
+}
+
+func init() {
+	RegisterChecker(CheckerDNS, dnsChecker{})
+}
+
+// CheckDNS resolves DNSRecordType for the monitored host using DNSResolver
+// (or the system resolver when empty). The monitor is UP when at least one
+// answer is returned and the answers satisfy DNSExpected and DNSExpectedRegex.
+func (nc *NetworkConfig) CheckDNS() (*CheckResults, error) {
+	result := &CheckResults{
+		URL:       nc.URL,
+		LastCheck: time.Now(),
+		IsUp:      false,
+	}
+
+	dnsTimeout := durationOrDefault(nc.DNSTimeout, 5*time.Second)
+	dialTimeout := durationOrDefault(nc.DialTimeout, 10*time.Second)
+	totalTimeout := durationOrDefault(nc.Timeout, 30*time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), totalTimeout)
+	defer cancel()
+
+	dnsCtx, dnsCancel := context.WithTimeout(ctx, dnsTimeout)
+	defer dnsCancel()
+
+	host := DNSHost(nc.URL)
+	recordType := strings.ToUpper(nc.DNSRecordType)
+	if recordType == "" {
+		recordType = RecordA
+	}
+
+	start := time.Now()
+	answers, err := lookupRecord(dnsCtx, newResolver(nc.DNSResolver, dialTimeout), recordType, host)
+	result.DNSTime = time.Since(start)
+	result.ResponseTime = result.DNSTime
+
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.Timeout() {
+			result.ErrorMessage = fmt.Sprintf("DNS resolution timeout (%v): %s %s", dnsTimeout, recordType, host)
+		} else {
+			result.ErrorMessage = fmt.Sprintf("DNS lookup failed for %s %s: %v", recordType, host, err)
+		}
+		return result, err
+	}
+
+	slices.Sort(answers)
+	result.Answers = answers
+
+	if len(answers) == 0 {
+		err := fmt.Errorf("no %s records found for %s", recordType, host)
+		result.ErrorMessage = err.Error()
+		return result, err
+	}
+
+	if err := nc.matchAnswers(recordType, answers); err != nil {
+		result.ErrorMessage = err.Error()
+		return result, err
+	}
+
+	result.IsUp = true
+
+	return result, nil
+}
+
+// matchAnswers compares the answers against the expected list and pattern
+func (nc *NetworkConfig) matchAnswers(recordType string, answers []string) error {
+	if len(nc.DNSExpected) > 0 {
+		expected := make([]string, 0, len(nc.DNSExpected))
+		for _, answer := range nc.DNSExpected {
+			expected = append(expected, normalizeAnswer(recordType, answer))
+		}
+		slices.Sort(expected)
+
+		if !slices.Equal(expected, answers) {
+			return fmt.Errorf("%w for %s %s: got [%s], expected [%s]", ErrDNSMismatch,
+				recordType, DNSHost(nc.URL), strings.Join(answers, ", "), strings.Join(expected, ", "))
+		}
+	}
+
+	if nc.DNSExpectedRegex != nil {
+		for _, answer := range answers {
+			if !nc.DNSExpectedRegex.MatchString(answer) {
+				return fmt.Errorf("%w for %s %s: %q does not match %q", ErrDNSMismatch,
+					recordType, DNSHost(nc.URL), answer, nc.DNSExpectedRegex)
+			}
+		}
+	}
+
+	return nil
+}
+
+func lookupRecord(ctx context.Context, resolver *net.Resolver, recordType string, host string) ([]string, error) {
+	var answers []string
+
+	switch recordType {
+	case RecordA, RecordAAAA:
+		network := "ip4"
+		if recordType == RecordAAAA {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			answers = append(answers, ip.String())
+		}
+	case RecordCNAME:
+		cname, err := resolver.LookupCNAME(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, cname)
+	case RecordMX:
+		records, err := resolver.LookupMX(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range records {
+			answers = append(answers, mx.Host)
+		}
+	case RecordTXT:
+		records, err := resolver.LookupTXT(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, records...)
+	case RecordNS:
+		records, err := resolver.LookupNS(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range records {
+			answers = append(answers, ns.Host)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported dns record type %q", recordType)
+	}
+
+	for i, answer := range answers {
+		answers[i] = normalizeAnswer(recordType, answer)
+	}
+
+	return answers, nil
+}
+
+// normalizeAnswer makes host names comparable by lowercasing them and
+// removing the trailing dot. TXT records are compared verbatim.
+func normalizeAnswer(recordType string, answer string) string {
+	if recordType == RecordTXT {
+		return answer
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if ip := net.ParseIP(answer); ip != nil {
+		return ip.String()
+	}
+
+	return strings.TrimSuffix(answer, ".")
+}
+
+// newResolver returns a resolver sending queries to address, or the system
+// resolver when address is empty. Port 53 is assumed when none is given.
+func newResolver(address string, dialTimeout time.Duration) *net.Resolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(strings.Trim(address, "[]"), "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: dialTimeout}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// DNSHost strips the optional dns:// scheme from a monitor URL, leaving the host name
+func DNSHost(rawURL string) string {
+	return strings.TrimPrefix(rawURL, "dns://")
+}
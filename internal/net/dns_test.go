@@ -0,0 +1,178 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// startDNSServer serves A, AAAA and TXT answers for the given names over UDP
+func startDNSServer(t *testing.T, records map[string][]string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var parser dnsmessage.Parser
+			header, err := parser.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			question, err := parser.Question()
+			if err != nil {
+				continue
+			}
+
+			builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true})
+			builder.EnableCompression()
+			_ = builder.StartQuestions()
+			_ = builder.Question(question)
+			_ = builder.StartAnswers()
+
+			name := strings.TrimSuffix(question.Name.String(), ".")
+			resource := dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60}
+			for _, answer := range records[name] {
+				switch question.Type {
+				case dnsmessage.TypeA:
+					ip := net.ParseIP(answer).To4()
+					if ip == nil {
+						continue
+					}
+					_ = builder.AResource(resource, dnsmessage.AResource{A: [4]byte(ip)})
+				case dnsmessage.TypeAAAA:
+					ip := net.ParseIP(answer)
+					if ip == nil || ip.To4() != nil {
+						continue
+					}
+					_ = builder.AAAAResource(resource, dnsmessage.AAAAResource{AAAA: [16]byte(ip)})
+				case dnsmessage.TypeTXT:
+					if net.ParseIP(answer) != nil {
+						continue
+					}
+					_ = builder.TXTResource(resource, dnsmessage.TXTResource{TXT: []string{answer}})
+				}
+			}
+
+			msg, err := builder.Finish()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(msg, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestCheckDNS(t *testing.T) {
+	resolver := startDNSServer(t, map[string][]string{
+		"example.test": {"192.0.2.10", "192.0.2.11", "v=spf1 -all"},
+	})
+
+	t.Run("expected answers match", func(t *testing.T) {
+		nc := NetworkConfig{
+			URL:           "dns://example.test",
+			Timeout:       2 * time.Second,
+			DNSResolver:   resolver,
+			DNSRecordType: RecordA,
+			DNSExpected:   []string{"192.0.2.11", "192.0.2.10"},
+		}
+
+		results, err := nc.CheckDNS()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !results.IsUp {
+			t.Fatalf("expected dns check to be up, got %+v", results)
+		}
+		if len(results.Answers) != 2 {
+			t.Errorf("expected 2 answers, got %v", results.Answers)
+		}
+	})
+
+	t.Run("expected answers mismatch", func(t *testing.T) {
+		nc := NetworkConfig{
+			URL:           "dns://example.test",
+			Timeout:       2 * time.Second,
+			DNSResolver:   resolver,
+			DNSRecordType: RecordA,
+			DNSExpected:   []string{"192.0.2.10"},
+		}
+
+		results, err := nc.CheckDNS()
+		if !errors.Is(err, ErrDNSMismatch) {
+			t.Fatalf("expected ErrDNSMismatch, got %v", err)
+		}
+		if results.IsUp {
+			t.Errorf("expected dns check to be down")
+		}
+		if len(results.Answers) != 2 {
+			t.Errorf("expected answers to be recorded, got %v", results.Answers)
+		}
+	})
+
+	t.Run("regex match on txt record", func(t *testing.T) {
+		nc := NetworkConfig{
+			URL:              "dns://example.test",
+			Timeout:          2 * time.Second,
+			DNSResolver:      resolver,
+			DNSRecordType:    RecordTXT,
+			DNSExpectedRegex: regexp.MustCompile(`^v=spf1 `),
+		}
+
+		results, err := nc.CheckDNS()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !results.IsUp {
+			t.Fatalf("expected dns check to be up, got %+v", results)
+		}
+	})
+
+	t.Run("regex mismatch", func(t *testing.T) {
+		nc := NetworkConfig{
+			URL:              "dns://example.test",
+			Timeout:          2 * time.Second,
+			DNSResolver:      resolver,
+			DNSRecordType:    RecordA,
+			DNSExpectedRegex: regexp.MustCompile(`^198\.51\.100\.`),
+		}
+
+		if _, err := nc.CheckDNS(); !errors.Is(err, ErrDNSMismatch) {
+			t.Fatalf("expected ErrDNSMismatch, got %v", err)
+		}
+	})
+
+	t.Run("no records", func(t *testing.T) {
+		nc := NetworkConfig{
+			URL:           "dns://missing.test",
+			Timeout:       2 * time.Second,
+			DNSResolver:   resolver,
+			DNSRecordType: RecordA,
+		}
+
+		results, err := nc.CheckDNS()
+		if err == nil {
+			t.Fatalf("expected an error, got none")
+		}
+		if results.IsUp {
+			t.Errorf("expected dns check to be down")
+		}
+	})
+}
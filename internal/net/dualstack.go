@@ -0,0 +1,59 @@
+package net
+
+import (
+	"sync"
+	"uptime-go/internal/models"
+)
+
+// checkBothFamilies checks the host over IPv4 and IPv6 separately and
+// concurrently. The returned result is the one of the first family that is
+// up, IPv4 first, with the result of each family listed in Families.
+func (nc *NetworkConfig) checkBothFamilies() (*CheckResults, error) {
+	if isIPAddress(nc.URL) {
+		return nc.checkWebsite()
+	}
+
+	families := []string{ipTypeV4, ipTypeV6}
+	results := make([]*CheckResults, len(families))
+	errs := make([]error, len(families))
+
+	var wg sync.WaitGroup
+	for i, family := range families {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			single := *nc
+			single.IPType = family
+			results[i], errs[i] = single.CheckWebsite()
+		}()
+	}
+	wg.Wait()
+
+	selected := 0
+	for i, result := range results {
+		if result.IsUp {
+			selected = i
+			break
+		}
+	}
+
+	var addresses []models.AddressResult
+	familyResults := make([]models.FamilyResult, len(results))
+	for i, familyResult := range results {
+		addresses = append(addresses, familyResult.Addresses...)
+		familyResults[i] = models.FamilyResult{
+			Family:       families[i],
+			IsUp:         familyResult.IsUp,
+			StatusCode:   familyResult.StatusCode,
+			ResponseTime: familyResult.ResponseTime.Milliseconds(),
+			Error:        familyResult.ErrorMessage,
+		}
+	}
+
+	result := results[selected]
+	result.Addresses = addresses
+	result.Families = familyResults
+
+	return result, errs[selected]
+}
@@ -0,0 +1,106 @@
+package net
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// listenHTTP serves 200 OK on network ("tcp4" or "tcp6") at address
+func listenHTTP(t *testing.T, network, address string) (string, func()) {
+	t.Helper()
+
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		t.Skipf("%s not available: %v", network, err)
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() { _ = server.Close() })
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	return port, func() { _ = server.Close() }
+}
+
+func TestCheckWebsiteBothFamilies(t *testing.T) {
+	resolver := startDNSServer(t, map[string][]string{
+		"dual.test": {"127.0.0.1", "::1"},
+	})
+	previous := hostResolver
+	hostResolver = newResolver(resolver, time.Second)
+	t.Cleanup(func() { hostResolver = previous })
+
+	// The IPv6 server shares the port of the IPv4 one when possible
+	port, closeV4 := listenHTTP(t, "tcp4", "127.0.0.1:0")
+	nc := &NetworkConfig{URL: "http://dual.test:" + port + "/", Timeout: 5 * time.Second, IPType: "both"}
+
+	t.Run("ipv6 unreachable", func(t *testing.T) {
+		result, err := nc.CheckWebsite()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsUp || len(result.Families) != 2 {
+			t.Fatalf("expected the IPv4 result with both families, got %+v", result)
+		}
+
+		v4, v6 := result.Families[0], result.Families[1]
+		if v4.Family != "ipv4" || !v4.IsUp || v4.StatusCode != http.StatusOK {
+			t.Errorf("expected IPv4 to be up, got %+v", v4)
+		}
+		if v6.Family != "ipv6" || v6.IsUp || v6.Error == "" {
+			t.Errorf("expected IPv6 to be down, got %+v", v6)
+		}
+	})
+
+	t.Run("per address", func(t *testing.T) {
+		perAddress := *nc
+		perAddress.AllAddresses = true
+
+		result, _ := perAddress.CheckWebsite()
+		if len(result.Addresses) != 2 || result.Addresses[0].IP != "127.0.0.1" || result.Addresses[1].IP != "::1" {
+			t.Errorf("expected the addresses of both families, got %+v", result.Addresses)
+		}
+	})
+
+	t.Run("both families up", func(t *testing.T) {
+		listenHTTP(t, "tcp6", "[::1]:"+port)
+
+		result, err := nc.CheckWebsite()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, family := range result.Families {
+			if !family.IsUp {
+				t.Errorf("expected %s to be up, got %+v", family.Family, family)
+			}
+		}
+	})
+
+	t.Run("ipv4 unreachable", func(t *testing.T) {
+		closeV4()
+		listenHTTP(t, "tcp6", "[::1]:"+port)
+
+		result, err := nc.CheckWebsite()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsUp || result.Families[0].IsUp || !result.Families[1].IsUp {
+			t.Errorf("expected only IPv6 to be up, got %+v", result.Families)
+		}
+	})
+
+	t.Run("ip address", func(t *testing.T) {
+		literal := &NetworkConfig{URL: "http://127.0.0.1:" + port + "/", Timeout: 5 * time.Second, IPType: "both"}
+
+		result, _ := literal.CheckWebsite()
+		if result.Families != nil || !strings.Contains(result.ErrorMessage, "refused") {
+			t.Errorf("expected a single check of the address, got %+v", result)
+		}
+	})
+}
@@ -0,0 +1,274 @@
+package net
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/helper"
+)
+
+// ChannelEmail sends notifications by email over SMTP
+const ChannelEmail = "email"
+
+// SMTP connection security modes
+const (
+	SMTPStartTLS = "starttls"
+	SMTPTLS      = "tls"
+	SMTPNone     = "none"
+)
+
+const defaultSubjectTemplate = `{{.Title}}`
+
+const defaultTextTemplate = `{{.Title}}
+
+{{.Text}}
+{{range .Fields}}
+{{.Name}}: {{.Value}}{{end}}
+
+Time: {{.Time.Format "2006-01-02 15:04:05 MST"}}
+`
+
+const defaultHTMLTemplate = `<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif;">
+  <h2 style="color: #{{.Color}};">{{.Title}}</h2>
+  <p>{{.Text}}</p>
+  <table cellpadding="4" style="border-collapse: collapse;">
+    {{- range .Fields}}
+    <tr><th align="left">{{.Name}}</th><td>{{.Value}}</td></tr>
+    {{- end}}
+    <tr><th align="left">time</th><td>{{.Time.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+  </table>
+</body>
+</html>
+`
+
+// emailNotifier sends a multipart text and HTML mail for each notification
+type emailNotifier struct {
+	name       string
+	host       string
+	port       int
+	username   string
+	password   string
+	security   string
+	skipVerify bool
+	from       string
+	to         []string
+
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+func init() {
+	RegisterNotifier(ChannelEmail, newEmailNotifier)
+}
+
+func newEmailNotifier(cfg configuration.ChannelConfig) (Notifier, error) {
+	if cfg.SMTPHost == "" {
+		return nil, fmt.Errorf("email channel %q requires smtp_host", cfg.Name)
+	}
+	if cfg.From == "" || len(cfg.To) == 0 {
+		return nil, fmt.Errorf("email channel %q requires from and to", cfg.Name)
+	}
+
+	security := strings.ToLower(strings.TrimSpace(cfg.SMTPTLS))
+	port := cfg.SMTPPort
+	switch security {
+	case "", SMTPStartTLS:
+		security = SMTPStartTLS
+		if port == 0 {
+			port = 587
+		}
+	case SMTPTLS:
+		if port == 0 {
+			port = 465
+		}
+	case SMTPNone:
+		if port == 0 {
+			port = 25
+		}
+	default:
+		return nil, fmt.Errorf("email channel %q has invalid smtp_tls %q", cfg.Name, cfg.SMTPTLS)
+	}
+
+	subject, err := texttemplate.New("subject").Parse(helper.OrDefault(cfg.SubjectTemplate, defaultSubjectTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("email channel %q has invalid subject_template: %w", cfg.Name, err)
+	}
+	text, err := texttemplate.New("text").Parse(helper.OrDefault(cfg.TextTemplate, defaultTextTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("email channel %q has invalid text_template: %w", cfg.Name, err)
+	}
+	html, err := htmltemplate.New("html").Parse(helper.OrDefault(cfg.HTMLTemplate, defaultHTMLTemplate))
+	if err != nil {
+		return nil, fmt.Errorf("email channel %q has invalid html_template: %w", cfg.Name, err)
+	}
+
+	return &emailNotifier{
+		name:       cfg.Name,
+		host:       cfg.SMTPHost,
+		port:       port,
+		username:   cfg.SMTPUsername,
+		password:   cfg.SMTPPassword,
+		security:   security,
+		skipVerify: cfg.SMTPSkipVerify,
+		from:       cfg.From,
+		to:         cfg.To,
+		subject:    subject,
+		text:       text,
+		html:       html,
+	}, nil
+}
+
+func (e *emailNotifier) Name() string {
+	return e.name
+}
+
+func (e *emailNotifier) Notify(n *Notification) error {
+	message, err := e.render(n)
+	if err != nil {
+		return err
+	}
+
+	return e.send(message)
+}
+
+// render builds the RFC 5322 message for n
+func (e *emailNotifier) render(n *Notification) ([]byte, error) {
+	var subject, text, html bytes.Buffer
+
+	if err := e.subject.Execute(&subject, n); err != nil {
+		return nil, fmt.Errorf("failed to render subject: %w", err)
+	}
+	if err := e.text.Execute(&text, n); err != nil {
+		return nil, fmt.Errorf("failed to render text body: %w", err)
+	}
+	if err := e.html.Execute(&html, n); err != nil {
+		return nil, fmt.Errorf("failed to render html body: %w", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=UTF-8", text.Bytes()},
+		{"text/html; charset=UTF-8", html.Bytes()},
+	} {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	headers := [][2]string{
+		{"From", e.from},
+		{"To", strings.Join(e.to, ", ")},
+		{"Subject", mime.QEncoding.Encode("UTF-8", strings.TrimSpace(subject.String()))},
+		{"Date", n.Time.Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@uptime-go>", helper.GenerateRandomID())},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + writer.Boundary()},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", header[0], header[1])
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+func (e *emailNotifier) send(message []byte) error {
+	address := net.JoinHostPort(e.host, strconv.Itoa(e.port))
+	tlsConfig := &tls.Config{
+		ServerName:         e.host,
+		InsecureSkipVerify: e.skipVerify,
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	var conn net.Conn
+	var err error
+	if e.security == SMTPTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp server %s: %w", address, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	client, err := smtp.NewClient(conn, e.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session with %s: %w", address, err)
+	}
+	defer client.Close()
+
+	if e.security == SMTPStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", address)
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("failed to start tls with %s: %w", address, err)
+		}
+	}
+
+	if e.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", e.username, e.password, e.host)); err != nil {
+			return fmt.Errorf("smtp authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(e.from); err != nil {
+		return fmt.Errorf("smtp MAIL FROM rejected: %w", err)
+	}
+	for _, to := range e.to {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("smtp RCPT TO %s rejected: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA rejected: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,302 @@
+package net
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+	"uptime-go/internal/configuration"
+)
+
+// selfSignedCert returns a certificate valid for 127.0.0.1 and localhost
+func selfSignedCert(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// smtpSink is a minimal SMTP server recording the received session
+type smtpSink struct {
+	port     int
+	auth     chan string
+	messages chan string
+}
+
+func startSMTPSink(t *testing.T, implicitTLS bool) *smtpSink {
+	t.Helper()
+
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{selfSignedCert(t)}}
+
+	var listener net.Listener
+	var err error
+	if implicitTLS {
+		listener, err = tls.Listen("tcp4", "127.0.0.1:0", tlsConfig)
+	} else {
+		listener, err = net.Listen("tcp4", "127.0.0.1:0")
+	}
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	sink := &smtpSink{
+		port:     listener.Addr().(*net.TCPAddr).Port,
+		auth:     make(chan string, 1),
+		messages: make(chan string, 1),
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go sink.serve(conn, tlsConfig, !implicitTLS)
+		}
+	}()
+
+	return sink
+}
+
+func (s *smtpSink) serve(conn net.Conn, tlsConfig *tls.Config, offerStartTLS bool) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost ESMTP sink")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.Fields(line + " ")[0])
+
+		switch command {
+		case "EHLO", "HELO":
+			reply("250-localhost")
+			if offerStartTLS {
+				reply("250-STARTTLS")
+			}
+			reply("250 AUTH PLAIN")
+		case "STARTTLS":
+			reply("220 ready to start TLS")
+			tlsConn := tls.Server(conn, tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			reader = bufio.NewReader(conn)
+			offerStartTLS = false
+		case "AUTH":
+			fields := strings.Fields(line)
+			decoded, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+			s.auth <- strings.ReplaceAll(string(decoded), "\x00", ":")
+			reply("235 authenticated")
+		case "MAIL", "RCPT":
+			reply("250 ok")
+		case "DATA":
+			reply("354 end with .")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.messages <- data.String()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func (s *smtpSink) message(t *testing.T) *mail.Message {
+	t.Helper()
+
+	select {
+	case raw := <-s.messages:
+		message, err := mail.ReadMessage(strings.NewReader(raw))
+		if err != nil {
+			t.Fatalf("invalid message: %v", err)
+		}
+		return message
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message received")
+		return nil
+	}
+}
+
+// messageParts returns the decoded text and html parts of a message
+func messageParts(t *testing.T, message *mail.Message) (string, string) {
+	t.Helper()
+
+	_, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("invalid content type: %v", err)
+	}
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if err != nil {
+			break
+		}
+		body, _ := io.ReadAll(quotedprintable.NewReader(part))
+		mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[mediaType] = string(body)
+	}
+
+	return parts["text/plain"], parts["text/html"]
+}
+
+func TestEmailNotifier(t *testing.T) {
+	for _, security := range []string{SMTPStartTLS, SMTPTLS, SMTPNone} {
+		t.Run(security, func(t *testing.T) {
+			sink := startSMTPSink(t, security == SMTPTLS)
+
+			notifier, err := NewNotifier(configuration.ChannelConfig{
+				Name:           "oncall",
+				Type:           ChannelEmail,
+				SMTPHost:       "127.0.0.1",
+				SMTPPort:       sink.port,
+				SMTPTLS:        security,
+				SMTPSkipVerify: true,
+				SMTPUsername:   "uptime",
+				SMTPPassword:   "secret",
+				From:           "uptime@example.com",
+				To:             []string{"oncall@example.com"},
+			})
+			if err != nil {
+				t.Fatalf("failed to create notifier: %v", err)
+			}
+
+			if err := notifier.Notify(testNotification(false)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			select {
+			case auth := <-sink.auth:
+				if auth != ":uptime:secret" {
+					t.Errorf("unexpected credentials %q", auth)
+				}
+			default:
+				t.Error("expected smtp authentication")
+			}
+
+			message := sink.message(t)
+			if subject := message.Header.Get("Subject"); subject != "[DOWN] https://example.com" {
+				t.Errorf("unexpected subject %q", subject)
+			}
+			if to := message.Header.Get("To"); to != "oncall@example.com" {
+				t.Errorf("unexpected recipient %q", to)
+			}
+
+			text, html := messageParts(t, message)
+			if !strings.Contains(text, "Request timed out after 5s") || !strings.Contains(text, "error_message: timeout") {
+				t.Errorf("unexpected text body:\n%s", text)
+			}
+			if !strings.Contains(html, "<h2 style=\"color: #E01E5A;\">[DOWN] https://example.com</h2>") {
+				t.Errorf("unexpected html body:\n%s", html)
+			}
+		})
+	}
+}
+
+func TestEmailNotifierCustomTemplates(t *testing.T) {
+	sink := startSMTPSink(t, false)
+
+	notifier, err := NewNotifier(configuration.ChannelConfig{
+		Name:            "oncall",
+		Type:            ChannelEmail,
+		SMTPHost:        "127.0.0.1",
+		SMTPPort:        sink.port,
+		SMTPTLS:         SMTPNone,
+		From:            "uptime@example.com",
+		To:              []string{"a@example.com", "b@example.com"},
+		SubjectTemplate: `{{if .Resolved}}Resolved{{else}}Alert{{end}}: {{.Incident.Type}} on {{.URL}}`,
+		TextTemplate:    `Monitor {{.Monitor.URL}} - {{.Incident.Description}}`,
+		HTMLTemplate:    `<p>{{.Incident.Description}}</p>`,
+	})
+	if err != nil {
+		t.Fatalf("failed to create notifier: %v", err)
+	}
+
+	n := testNotification(true)
+	n.Incident.Description = "<script>alert(1)</script>"
+	if err := notifier.Notify(n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	message := sink.message(t)
+	if subject := message.Header.Get("Subject"); subject != "Resolved: timeout on https://example.com" {
+		t.Errorf("unexpected subject %q", subject)
+	}
+
+	text, html := messageParts(t, message)
+	if text != "Monitor https://example.com - <script>alert(1)</script>" {
+		t.Errorf("unexpected text body %q", text)
+	}
+	if html != "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>" {
+		t.Errorf("expected html body to be escaped, got %q", html)
+	}
+}
+
+func TestEmailNotifierInvalidConfig(t *testing.T) {
+	tests := []configuration.ChannelConfig{
+		{Name: "no-host", Type: ChannelEmail, From: "a@example.com", To: []string{"b@example.com"}},
+		{Name: "no-recipient", Type: ChannelEmail, SMTPHost: "localhost", From: "a@example.com"},
+		{Name: "bad-tls", Type: ChannelEmail, SMTPHost: "localhost", From: "a@example.com", To: []string{"b@example.com"}, SMTPTLS: "ssl3"},
+		{Name: "bad-template", Type: ChannelEmail, SMTPHost: "localhost", From: "a@example.com", To: []string{"b@example.com"}, SubjectTemplate: "{{.Title"},
+	}
+
+	for _, cfg := range tests {
+		if _, err := NewNotifier(cfg); err == nil {
+			t.Errorf("%s: expected error", cfg.Name)
+		}
+	}
+}
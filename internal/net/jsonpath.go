@@ -0,0 +1,282 @@
+package net
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"uptime-go/internal/helper"
+	"uptime-go/internal/models"
+)
+
+// Operators supported by JSON assertions
+const (
+	OpEqual          = "=="
+	OpNotEqual       = "!="
+	OpLess           = "<"
+	OpLessOrEqual    = "<="
+	OpGreater        = ">"
+	OpGreaterOrEqual = ">="
+	OpContains       = "contains"
+	OpNotContains    = "not_contains"
+	OpMatches        = "matches"
+	OpExists         = "exists"
+	OpNotExists      = "not_exists"
+)
+
+// ErrJSONAssertion is returned when one or more JSON assertions fail
+var ErrJSONAssertion = errors.New("json assertion failed")
+
+// assertJSON evaluates every assertion against the JSON body and returns
+// a description of each failed assertion.
+func assertJSON(body []byte, assertions []models.JSONAssertion) []string {
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return []string{fmt.Sprintf("response is not valid JSON: %v", err)}
+	}
+
+	var failed []string
+	for _, assertion := range assertions {
+		if err := evalAssertion(doc, assertion); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", FormatJSONAssertion(assertion), err))
+		}
+	}
+
+	return failed
+}
+
+// FormatJSONAssertion renders an assertion as "path operator value"
+func FormatJSONAssertion(assertion models.JSONAssertion) string {
+	operator := normalizeOperator(assertion.Operator)
+	if operator == OpExists || operator == OpNotExists {
+		return fmt.Sprintf("%s %s", assertion.Path, operator)
+	}
+
+	value, _ := json.Marshal(assertion.Value)
+	return fmt.Sprintf("%s %s %s", assertion.Path, operator, value)
+}
+
+func normalizeOperator(operator string) string {
+	operator = strings.ToLower(strings.TrimSpace(operator))
+	switch operator {
+	case "", "=", "eq":
+		return OpEqual
+	case "ne":
+		return OpNotEqual
+	case "lt":
+		return OpLess
+	case "lte":
+		return OpLessOrEqual
+	case "gt":
+		return OpGreater
+	case "gte":
+		return OpGreaterOrEqual
+	default:
+		return operator
+	}
+}
+
+func evalAssertion(doc any, assertion models.JSONAssertion) error {
+	operator := normalizeOperator(assertion.Operator)
+
+	actual, found, err := lookupJSONPath(doc, assertion.Path)
+	if err != nil {
+		return err
+	}
+
+	switch operator {
+	case OpExists:
+		if !found {
+			return errors.New("path not found")
+		}
+		return nil
+	case OpNotExists:
+		if found {
+			return fmt.Errorf("path exists (got %s)", formatJSONValue(actual))
+		}
+		return nil
+	}
+
+	if !found {
+		return errors.New("path not found")
+	}
+
+	expected := assertion.Value
+
+	switch operator {
+	case OpEqual:
+		if !jsonEqual(actual, expected) {
+			return fmt.Errorf("got %s", formatJSONValue(actual))
+		}
+	case OpNotEqual:
+		if jsonEqual(actual, expected) {
+			return fmt.Errorf("got %s", formatJSONValue(actual))
+		}
+	case OpLess, OpLessOrEqual, OpGreater, OpGreaterOrEqual:
+		a, okA := toFloat(actual)
+		b, okB := toFloat(expected)
+		if !okA || !okB {
+			return fmt.Errorf("cannot compare %s as a number", formatJSONValue(actual))
+		}
+		if !compareNumbers(a, b, operator) {
+			return fmt.Errorf("got %s", formatJSONValue(actual))
+		}
+	case OpContains, OpNotContains:
+		contains := jsonContains(actual, expected)
+		if contains != (operator == OpContains) {
+			return fmt.Errorf("got %s", formatJSONValue(actual))
+		}
+	case OpMatches:
+		// Compiled when the configuration is loaded
+		if assertion.Pattern == nil {
+			return fmt.Errorf("invalid pattern %q", fmt.Sprint(expected))
+		}
+		if !assertion.Pattern.MatchString(jsonString(actual)) {
+			return fmt.Errorf("got %s", formatJSONValue(actual))
+		}
+	default:
+		return fmt.Errorf("unsupported operator %q", assertion.Operator)
+	}
+
+	return nil
+}
+
+func compareNumbers(a, b float64, operator string) bool {
+	switch operator {
+	case OpLess:
+		return a < b
+	case OpLessOrEqual:
+		return a <= b
+	case OpGreater:
+		return a > b
+	default:
+		return a >= b
+	}
+}
+
+// jsonEqual compares numbers numerically and everything else by value
+func jsonEqual(actual, expected any) bool {
+	if a, ok := toFloat(actual); ok {
+		if b, ok := toFloat(expected); ok {
+			return a == b
+		}
+	}
+
+	if _, isString := actual.(string); isString {
+		return actual == fmt.Sprint(expected)
+	}
+
+	return reflect.DeepEqual(actual, normalizeJSONValue(expected))
+}
+
+func jsonContains(actual, expected any) bool {
+	switch value := actual.(type) {
+	case string:
+		return strings.Contains(value, fmt.Sprint(expected))
+	case []any:
+		for _, item := range value {
+			if jsonEqual(item, expected) {
+				return true
+			}
+		}
+	case map[string]any:
+		_, ok := value[fmt.Sprint(expected)]
+		return ok
+	}
+
+	return false
+}
+
+// normalizeJSONValue converts configuration values to the types produced by encoding/json
+func normalizeJSONValue(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+
+	return normalized
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func jsonString(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+func formatJSONValue(value any) string {
+	return helper.Truncate(jsonString(value), 64)
+}
+
+// lookupJSONPath returns the value at path and whether it exists
+func lookupJSONPath(doc any, path string) (any, bool, error) {
+	segments, err := helper.ParseJSONPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	current := doc
+	for _, segment := range segments {
+		if segment.IsIndex {
+			array, ok := current.([]any)
+			if !ok {
+				return nil, false, nil
+			}
+			index := segment.Index
+			if index < 0 {
+				index += len(array)
+			}
+			if index < 0 || index >= len(array) {
+				return nil, false, nil
+			}
+			current = array[index]
+			continue
+		}
+
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false, nil
+		}
+		current, ok = object[segment.Key]
+		if !ok {
+			return nil, false, nil
+		}
+	}
+
+	return current, true, nil
+}
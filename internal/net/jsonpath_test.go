@@ -0,0 +1,84 @@
+package net
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"uptime-go/internal/models"
+)
+
+const healthDocument = `{
+	"status": "degraded",
+	"version": "1.4.2",
+	"db": {"latency_ms": 350, "connected": true},
+	"checks": [{"name": "cache", "status": "ok"}, {"name": "queue", "status": "failing"}],
+	"regions": ["eu-west", "us-east"]
+}`
+
+func TestAssertJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		assertion  models.JSONAssertion
+		expectPass bool
+	}{
+		{name: "string equal", assertion: models.JSONAssertion{Path: "$.status", Operator: "==", Value: "degraded"}, expectPass: true},
+		{name: "string not equal", assertion: models.JSONAssertion{Path: "$.status", Operator: "==", Value: "ok"}, expectPass: false},
+		{name: "number less than", assertion: models.JSONAssertion{Path: "$.db.latency_ms", Operator: "<", Value: 200}, expectPass: false},
+		{name: "number greater or equal", assertion: models.JSONAssertion{Path: "$.db.latency_ms", Operator: ">=", Value: 350}, expectPass: true},
+		{name: "boolean equal", assertion: models.JSONAssertion{Path: "$.db.connected", Operator: "==", Value: true}, expectPass: true},
+		{name: "array index", assertion: models.JSONAssertion{Path: "$.checks[1].status", Operator: "!=", Value: "failing"}, expectPass: false},
+		{name: "bracket key", assertion: models.JSONAssertion{Path: "$['checks'][0]['name']", Operator: "==", Value: "cache"}, expectPass: true},
+		{name: "array contains", assertion: models.JSONAssertion{Path: "$.regions", Operator: "contains", Value: "us-east"}, expectPass: true},
+		{name: "regex matches", assertion: models.JSONAssertion{Path: "$.version", Operator: "matches", Value: `^1\.4\.`, Pattern: regexp.MustCompile(`^1\.4\.`)}, expectPass: true},
+		{name: "exists", assertion: models.JSONAssertion{Path: "$.db", Operator: "exists"}, expectPass: true},
+		{name: "missing path", assertion: models.JSONAssertion{Path: "$.cache.hits", Operator: "exists"}, expectPass: false},
+		{name: "not exists", assertion: models.JSONAssertion{Path: "$.error", Operator: "not_exists"}, expectPass: true},
+		{name: "invalid path", assertion: models.JSONAssertion{Path: "status", Operator: "=="}, expectPass: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failed := assertJSON([]byte(healthDocument), []models.JSONAssertion{tt.assertion})
+			if passed := len(failed) == 0; passed != tt.expectPass {
+				t.Errorf("expected pass=%v, got failures %v", tt.expectPass, failed)
+			}
+		})
+	}
+}
+
+func TestCheckWebsiteJSONAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(healthDocument))
+	}))
+	defer server.Close()
+
+	nc := NetworkConfig{
+		URL:     server.URL,
+		Timeout: 5 * time.Second,
+		JSONAssertions: []models.JSONAssertion{
+			{Path: "$.status", Operator: "==", Value: "ok"},
+			{Path: "$.db.latency_ms", Operator: "<", Value: 200},
+			{Path: "$.db.connected", Operator: "==", Value: true},
+		},
+	}
+
+	results, err := nc.CheckWebsite()
+	if !errors.Is(err, ErrJSONAssertion) {
+		t.Fatalf("expected ErrJSONAssertion, got %v", err)
+	}
+	if results.IsUp {
+		t.Errorf("expected check to be down")
+	}
+	if len(results.FailedAssertions) != 2 {
+		t.Fatalf("expected 2 failed assertions, got %v", results.FailedAssertions)
+	}
+	if !strings.Contains(results.FailedAssertions[1], "$.db.latency_ms < 200: got 350") {
+		t.Errorf("unexpected failure description: %q", results.FailedAssertions[1])
+	}
+}
@@ -3,19 +3,28 @@ package net
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
+	"os"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
 
+	"uptime-go/internal/helper"
+	"uptime-go/internal/models"
 	"uptime-go/internal/version"
 )
 
+// hostResolver resolves the hosts of HTTP checks
+var hostResolver = net.DefaultResolver
+
 const (
 	ipTypeBoth = "both"
 	ipTypeV4   = "ipv4"
@@ -30,11 +39,58 @@ type NetworkConfig struct {
 	SkipSSL         bool
 	IPType          string
 
+	// AllAddresses checks every address the host resolves to instead of
+	// the first one, each result is listed in CheckResults.Addresses
+	AllAddresses bool
+
+	// Address the host is pinned to by the all addresses check
+	pinnedHost string
+	pinnedIP   net.IP
+
+	// AcceptedStatusCodes lists the status code patterns treated as UP
+	// (e.g. "200-299", "401", "3xx"). Empty means 200-399.
+	AcceptedStatusCodes []string
+
+	// Response body assertions
+	BodyContains    []string
+	BodyNotContains []string
+	BodyRegex       *regexp.Regexp
+	MaxBodySize     int64
+	JSONAssertions  []models.JSONAssertion
+
+	// HTTP request options
+	Method            string
+	Headers           map[string]string
+	Body              string
+	BasicAuthUsername string
+	BasicAuthPassword string
+	BearerToken       string
+
+	// TLS client options
+	CAFile         string // PEM bundle trusted in addition to the system roots
+	ClientCertFile string
+	ClientKeyFile  string
+	ServerName     string // overrides the SNI and the name the certificate is verified against
+
+	// Proxy options, Proxy takes precedence over the environment
+	Proxy                string // http, https, socks5 or socks5h url with optional credentials
+	ProxyFromEnvironment bool   // use HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+
 	// Granular timeouts for different phases
 	DNSTimeout            time.Duration
 	DialTimeout           time.Duration
 	TLSHandshakeTimeout   time.Duration
 	ResponseHeaderTimeout time.Duration
+
+	// TCP monitor options
+	TCPPayload string
+	TCPExpect  *regexp.Regexp
+
+	// DNS monitor options
+	DNSResolver      string
+	DNSRecordType    string
+	DNSExpected      []string
+	DNSExpectedRegex *regexp.Regexp
 }
 
 type CheckResults struct {
@@ -45,15 +101,41 @@ type CheckResults struct {
 	StatusCode     int
 	ErrorMessage   string
 	SSLExpiredDate *time.Time
+	Answers        []string // DNS answers returned by the dns checker
+	BodySnippet    string   // Excerpt of the body when an assertion failed
 
-	// Phase timing breakdown (for debugging)
+	FailedAssertions []string // Failed JSON assertions with the actual values
+
+	Certificate *models.CertificateInfo // TLS inspection of the last connection
+
+	Addresses []models.AddressResult // Result of every address with AllAddresses
+	Families  []models.FamilyResult  // Result of IPv4 and IPv6 with ip_type both
+
+	// Phase timing breakdown (for debugging). Through a proxy, DNSTime and
+	// ConnectTime measure the connection to the proxy and ProxyTime the
+	// tunnel setup, the target is resolved by the proxy.
 	DNSTime       time.Duration
 	ConnectTime   time.Duration
+	ProxyTime     time.Duration
 	TLSTime       time.Duration
 	FirstByteTime time.Duration
 }
 
 func (nc *NetworkConfig) CheckWebsite() (*CheckResults, error) {
+	// Through a proxy the target is resolved by the proxy
+	if nc.Proxy != "" || nc.ProxyFromEnvironment {
+		return nc.checkWebsite()
+	}
+	if normalizeIPType(nc.IPType) == ipTypeBoth {
+		return nc.checkBothFamilies()
+	}
+	if nc.AllAddresses {
+		return nc.checkAllAddresses()
+	}
+	return nc.checkWebsite()
+}
+
+func (nc *NetworkConfig) checkWebsite() (*CheckResults, error) {
 	result := &CheckResults{
 		URL:       nc.URL,
 		LastCheck: time.Now(),
@@ -89,8 +171,25 @@ func (nc *NetworkConfig) CheckWebsite() (*CheckResults, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), totalTimeout)
 	defer cancel()
 
-	// Track timing for each phase
-	var dnsStart, connectStart time.Time
+	tlsConfig, roots, err := nc.tlsConfig()
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return result, err
+	}
+
+	proxyFor, err := nc.proxyFunc()
+	if err != nil {
+		result.ErrorMessage = err.Error()
+		return result, err
+	}
+	trace := &proxyTrace{}
+
+	tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+		if trace.isProxyHandshake() {
+			return nc.verifyProxy(state, roots, trace.proxy)
+		}
+		return nc.verifyConnection(state, roots, result)
+	}
 
 	// Custom dialer with DNS and connection timeouts
 	dialer := &net.Dialer{
@@ -102,55 +201,16 @@ func (nc *NetworkConfig) CheckWebsite() (*CheckResults, error) {
 	transport := &http.Transport{
 		// Custom DialContext to track DNS and connection timing
 		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-			// DNS resolution phase
-			dnsStart = time.Now()
-
-			// Create sub-context with DNS timeout
-			dnsCtx, dnsCancel := context.WithTimeout(ctx, dnsTimeout)
-			defer dnsCancel()
-
-			// Resolve DNS
-			host, port, err := net.SplitHostPort(addr)
-			if err != nil {
-				return nil, fmt.Errorf("invalid address: %w", err)
-			}
-
-			ipVersion := normalizeIPType(nc.IPType)
-			lookupNetwork := "ip"
-			if ipVersion == ipTypeV4 {
-				lookupNetwork = "ip4"
-			} else if ipVersion == ipTypeV6 {
-				lookupNetwork = "ip6"
-			}
-
-			// Use "ip", "ip4", or "ip6" network type for DNS lookup
-			ips, err := net.DefaultResolver.LookupIP(dnsCtx, lookupNetwork, host)
-			if err != nil {
-				return nil, fmt.Errorf("DNS resolution failed: %w", err)
-			}
-			result.DNSTime = time.Since(dnsStart)
-
-			if len(ips) == 0 {
-				switch ipVersion {
-				case ipTypeV4:
-					return nil, fmt.Errorf("no IPv4 addresses found for host: %s", host)
-				case ipTypeV6:
-					return nil, fmt.Errorf("no IPv6 addresses found for host: %s", host)
-				default:
-					return nil, fmt.Errorf("no IP addresses found for host: %s", host)
-				}
-			}
-
-			// TCP connection phase
-			connectStart = time.Now()
-			ipAddr := net.JoinHostPort(ips[0].String(), port)
-			conn, err := dialer.DialContext(ctx, network, ipAddr)
-			if err != nil {
-				return nil, fmt.Errorf("TCP connection failed: %w", err)
+			conn, err := nc.dialContext(ctx, dialer, dnsTimeout, network, addr, result)
+			if err == nil {
+				trace.dialed = time.Now()
 			}
-			result.ConnectTime = time.Since(connectStart)
+			return conn, err
+		},
 
-			return conn, nil
+		// Proxy of the request, the tunnel setup is timed by the trace
+		Proxy: func(req *http.Request) (*url.URL, error) {
+			return trace.start(proxyFor, req.URL)
 		},
 
 		// TLS handshake timeout
@@ -163,9 +223,7 @@ func (nc *NetworkConfig) CheckWebsite() (*CheckResults, error) {
 		ExpectContinueTimeout: 1 * time.Second,
 
 		// TLS configuration
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: nc.SkipSSL || isIPAddress(nc.URL),
-		},
+		TLSClientConfig: tlsConfig,
 
 		// Connection pool settings
 		MaxIdleConns:       10,
@@ -179,28 +237,30 @@ func (nc *NetworkConfig) CheckWebsite() (*CheckResults, error) {
 		Timeout:   totalTimeout,
 	}
 
-	// Handle redirects
-	if !nc.FollowRedirects {
-		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+	// Handle redirects. With an explicit accepted list, redirects are not
+	// followed: the redirect status itself is evaluated against the list, so
+	// a listed 3xx is up and any other one is down.
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !nc.FollowRedirects || len(nc.AcceptedStatusCodes) > 0 {
 			return http.ErrUseLastResponse
 		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nc.URL, nil)
+	req, err := nc.newRequest(httptrace.WithClientTrace(ctx, trace.clientTrace(result)))
 	if err != nil {
 		result.ErrorMessage = err.Error()
 		return result, err
 	}
 
-	req.Header.Set("User-Agent", "GenbuUptimePlugin/"+version.VERSION)
-	req.Header.Set("Accept", "*/*")
-	req.Header.Set("Connection", "close")
-
 	requestStart := time.Now()
 	resp, err := client.Do(req)
 	responseTime := time.Since(requestStart)
 	result.ResponseTime = responseTime
-	result.FirstByteTime = responseTime - result.DNSTime - result.ConnectTime
+	result.FirstByteTime = responseTime - result.DNSTime - result.ConnectTime - result.ProxyTime
 
 	if err != nil {
 		result.ErrorMessage = nc.categorizeError(err, dnsTimeout, dialTimeout)
@@ -208,13 +268,11 @@ func (nc *NetworkConfig) CheckWebsite() (*CheckResults, error) {
 	}
 	defer resp.Body.Close()
 
-	// Read at least some of the body to ensure the server is responsive
-	bodyBuf := make([]byte, 1024)
-	_, _ = io.ReadFull(resp.Body, bodyBuf)
+	// Read at least some of the body to ensure the server is responsive,
+	// or up to the configured limit when body assertions are set
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, nc.bodyLimit()))
 
-	// Treat redirects (3xx) as UP so 302 doesn't mark the monitor down.
-	success := resp.StatusCode >= 200 && resp.StatusCode < 400
-	result.IsUp = success
+	result.IsUp = nc.isAcceptedStatus(resp.StatusCode)
 	result.StatusCode = resp.StatusCode
 
 	// Extract TLS information
@@ -222,9 +280,207 @@ func (nc *NetworkConfig) CheckWebsite() (*CheckResults, error) {
 		result.SSLExpiredDate = &resp.TLS.PeerCertificates[0].NotAfter
 	}
 
+	if result.IsUp && nc.hasBodyAssertions() {
+		if err := nc.assertBody(body); err != nil {
+			result.IsUp = false
+			result.BodySnippet = bodySnippet(body)
+			result.ErrorMessage = err.Error()
+			return result, err
+		}
+
+		if failed, err := nc.assertJSONBody(body); err != nil {
+			result.IsUp = false
+			result.BodySnippet = bodySnippet(body)
+			result.FailedAssertions = failed
+			result.ErrorMessage = err.Error()
+			return result, err
+		}
+	}
+
 	return result, nil
 }
 
+// tlsConfig builds the client TLS configuration and the root pool the
+// certificate is verified against, nil for the system roots. The certificate
+// is verified by verifyConnection, after it has been inspected.
+func (nc *NetworkConfig) tlsConfig() (*tls.Config, *x509.CertPool, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: true,
+		ServerName:         nc.ServerName,
+	}
+
+	var roots *x509.CertPool
+	if nc.CAFile != "" {
+		bundle, err := os.ReadFile(nc.CAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read ca_file: %w", err)
+		}
+
+		roots, err = x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(bundle) {
+			return nil, nil, fmt.Errorf("no certificate found in ca_file %s", nc.CAFile)
+		}
+	}
+
+	if nc.ClientCertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(nc.ClientCertFile, nc.ClientKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, roots, nil
+}
+
+// verifyConnection records the TLS inspection of the connection. With
+// certificate monitoring, the handshake then fails when the certificate is
+// not valid for the host or not trusted, so that no header, body or client
+// certificate is sent to the server. Like before the inspection, targets
+// addressed by IP are not verified.
+func (nc *NetworkConfig) verifyConnection(state tls.ConnectionState, roots *x509.CertPool, result *CheckResults) error {
+	host := state.ServerName
+	if host == "" {
+		if u, err := url.Parse(nc.URL); err == nil {
+			host = u.Hostname()
+		}
+	}
+
+	info := inspectTLS(state, host, !nc.SkipSSL, roots, time.Now())
+	result.Certificate = info
+
+	if info == nil || !info.Verified {
+		return nil
+	}
+	if nc.ServerName == "" && isIPAddress(nc.URL) {
+		return nil
+	}
+	if !info.HostnameValid {
+		return fmt.Errorf("certificate is not valid for %s", host)
+	}
+	if !info.ChainTrusted {
+		return fmt.Errorf("certificate is not trusted: %s", info.ChainError)
+	}
+
+	return nil
+}
+
+// isAcceptedStatus reports whether the status code counts as UP.
+// Without configured patterns redirects (3xx) are treated as UP so 302
+// doesn't mark the monitor down.
+func (nc *NetworkConfig) isAcceptedStatus(code int) bool {
+	if len(nc.AcceptedStatusCodes) == 0 {
+		return code >= 200 && code < 400
+	}
+
+	return helper.MatchStatusCode(code, nc.AcceptedStatusCodes)
+}
+
+// newRequest builds the check request from the configured method, headers,
+// body and credentials. Configured headers override the default ones.
+func (nc *NetworkConfig) newRequest(ctx context.Context) (*http.Request, error) {
+	method := strings.ToUpper(nc.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if nc.Body != "" {
+		body = strings.NewReader(nc.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, nc.URL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "GenbuUptimePlugin/"+version.VERSION)
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Connection", "close")
+
+	for key, value := range nc.Headers {
+		req.Header.Set(key, value)
+	}
+
+	if nc.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+nc.BearerToken)
+	} else if nc.BasicAuthUsername != "" || nc.BasicAuthPassword != "" {
+		req.SetBasicAuth(nc.BasicAuthUsername, nc.BasicAuthPassword)
+	}
+
+	return req, nil
+}
+
+// dialContext resolves the host of addr using the configured IP family and
+// connects to the first address returned, recording DNS and connect timings.
+// A pinned host is not resolved again.
+func (nc *NetworkConfig) dialContext(ctx context.Context, dialer *net.Dialer, dnsTimeout time.Duration, network, addr string, result *CheckResults) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %w", err)
+	}
+
+	ips := []net.IP{nc.pinnedIP}
+	if nc.pinnedIP == nil || host != nc.pinnedHost {
+		// DNS resolution phase
+		dnsStart := time.Now()
+
+		// Create sub-context with DNS timeout
+		dnsCtx, dnsCancel := context.WithTimeout(ctx, dnsTimeout)
+		defer dnsCancel()
+
+		ips, err = nc.lookupIP(dnsCtx, host)
+		if err != nil {
+			return nil, err
+		}
+		result.DNSTime = time.Since(dnsStart)
+	}
+
+	// TCP connection phase
+	connectStart := time.Now()
+	ipAddr := net.JoinHostPort(ips[0].String(), port)
+	conn, err := dialer.DialContext(ctx, network, ipAddr)
+	if err != nil {
+		return nil, fmt.Errorf("TCP connection failed: %w", err)
+	}
+	result.ConnectTime = time.Since(connectStart)
+
+	return conn, nil
+}
+
+// lookupIP resolves host using the configured IP family
+func (nc *NetworkConfig) lookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	ipVersion := normalizeIPType(nc.IPType)
+	lookupNetwork := "ip"
+	if ipVersion == ipTypeV4 {
+		lookupNetwork = "ip4"
+	} else if ipVersion == ipTypeV6 {
+		lookupNetwork = "ip6"
+	}
+
+	// Use "ip", "ip4", or "ip6" network type for DNS lookup
+	ips, err := hostResolver.LookupIP(ctx, lookupNetwork, host)
+	if err != nil {
+		return nil, fmt.Errorf("DNS resolution failed: %w", err)
+	}
+
+	if len(ips) == 0 {
+		switch ipVersion {
+		case ipTypeV4:
+			return nil, fmt.Errorf("no IPv4 addresses found for host: %s", host)
+		case ipTypeV6:
+			return nil, fmt.Errorf("no IPv6 addresses found for host: %s", host)
+		default:
+			return nil, fmt.Errorf("no IP addresses found for host: %s", host)
+		}
+	}
+
+	return ips, nil
+}
+
 // categorizeError provides more detailed error messages based on the type of failure
 func (nc *NetworkConfig) categorizeError(err error, dnsTimeout, dialTimeout time.Duration) string {
 	// Check for context timeout
@@ -1,10 +1,13 @@
 package net
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -199,3 +202,180 @@ func TestCheckWebsiteIPv6Only(t *testing.T) {
 		t.Fatalf("expected IPv6 check to be up, got %+v", results)
 	}
 }
+
+func TestGetChecker(t *testing.T) {
+	for _, name := range []string{"", "http", "HTTP"} {
+		if _, err := GetChecker(name); err != nil {
+			t.Errorf("expected checker for %q, got error: %v", name, err)
+		}
+	}
+
+	if _, err := GetChecker("gopher"); err == nil {
+		t.Errorf("expected error for unknown monitor type")
+	}
+}
+
+func TestCheckWebsiteRequestOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		switch {
+		case r.Method != http.MethodPost:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.Header.Get("X-Api-Version") != "2":
+			w.WriteHeader(http.StatusBadRequest)
+		case string(body) != `{"ping":true}`:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		case r.URL.Path == "/bearer" && r.Header.Get("Authorization") != "Bearer s3cret":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/basic":
+			if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "hunter2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		nc       NetworkConfig
+		expectUp bool
+	}{
+		{
+			name:     "bearer token",
+			nc:       NetworkConfig{URL: server.URL + "/bearer", BearerToken: "s3cret"},
+			expectUp: true,
+		},
+		{
+			name:     "basic auth",
+			nc:       NetworkConfig{URL: server.URL + "/basic", BasicAuthUsername: "admin", BasicAuthPassword: "hunter2"},
+			expectUp: true,
+		},
+		{
+			name:     "wrong credentials",
+			nc:       NetworkConfig{URL: server.URL + "/basic", BasicAuthUsername: "admin", BasicAuthPassword: "wrong"},
+			expectUp: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.nc.Timeout = 5 * time.Second
+			tt.nc.Method = "post"
+			tt.nc.Body = `{"ping":true}`
+			tt.nc.Headers = map[string]string{"x-api-version": "2"}
+
+			results, err := tt.nc.CheckWebsite()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if results.IsUp != tt.expectUp {
+				t.Errorf("expected IsUp to be %v, got %v (status %d)", tt.expectUp, results.IsUp, results.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCheckWebsiteAcceptedStatusCodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/private":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		path           string
+		accepted       []string
+		expectUp       bool
+		expectedStatus int
+	}{
+		{name: "default accepts redirect", path: "/redirect", expectUp: true, expectedStatus: http.StatusOK},
+		{name: "default rejects 401", path: "/private", expectUp: false, expectedStatus: http.StatusUnauthorized},
+		{name: "listed 401 is up", path: "/private", accepted: []string{"2xx", "401"}, expectUp: true, expectedStatus: http.StatusUnauthorized},
+		{name: "unlisted redirect is down", path: "/redirect", accepted: []string{"200-299"}, expectUp: false, expectedStatus: http.StatusFound},
+		{name: "listed redirect is up", path: "/redirect", accepted: []string{"200-299", "3xx"}, expectUp: true, expectedStatus: http.StatusFound},
+		{name: "only redirects accepted", path: "/redirect", accepted: []string{"3xx"}, expectUp: true, expectedStatus: http.StatusFound},
+		{name: "redirect expected but not sent", path: "/ok", accepted: []string{"3xx"}, expectUp: false, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nc := NetworkConfig{
+				URL:                 server.URL + tt.path,
+				Timeout:             5 * time.Second,
+				FollowRedirects:     true,
+				AcceptedStatusCodes: tt.accepted,
+			}
+
+			results, err := nc.CheckWebsite()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if results.IsUp != tt.expectUp {
+				t.Errorf("expected IsUp to be %v, got %v", tt.expectUp, results.IsUp)
+			}
+			if results.StatusCode != tt.expectedStatus {
+				t.Errorf("expected status code %d, got %d", tt.expectedStatus, results.StatusCode)
+			}
+		})
+	}
+}
+
+func TestCheckWebsiteBodyAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("<html><body><h1>Service Unavailable</h1> database connection failed</body></html>"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		nc       NetworkConfig
+		expectUp bool
+	}{
+		{name: "contains", nc: NetworkConfig{BodyContains: []string{"<html>", "database"}}, expectUp: true},
+		{name: "missing keyword", nc: NetworkConfig{BodyContains: []string{"Welcome"}}, expectUp: false},
+		{name: "forbidden keyword", nc: NetworkConfig{BodyNotContains: []string{"Service Unavailable"}}, expectUp: false},
+		{name: "regex match", nc: NetworkConfig{BodyRegex: regexp.MustCompile(`(?i)<h1>service \w+</h1>`)}, expectUp: true},
+		{name: "regex mismatch", nc: NetworkConfig{BodyRegex: regexp.MustCompile(`"status":\s*"ok"`)}, expectUp: false},
+		{name: "keyword beyond max body size", nc: NetworkConfig{BodyContains: []string{"database"}, MaxBodySize: 16}, expectUp: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.nc.URL = server.URL
+			tt.nc.Timeout = 5 * time.Second
+
+			results, err := tt.nc.CheckWebsite()
+			if results.IsUp != tt.expectUp {
+				t.Fatalf("expected IsUp to be %v, got %v", tt.expectUp, results.IsUp)
+			}
+
+			if tt.expectUp {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrKeywordMismatch) {
+				t.Errorf("expected ErrKeywordMismatch, got %v", err)
+			}
+			if results.BodySnippet == "" {
+				t.Errorf("expected body snippet to be set")
+			}
+		})
+	}
+}
+
@@ -0,0 +1,316 @@
+package net
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/incident"
+	"uptime-go/internal/models"
+	"uptime-go/internal/version"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Notification channel types
+const (
+	ChannelSlack   = "slack"
+	ChannelDiscord = "discord"
+	ChannelTeams   = "teams"
+)
+
+// Notification is an incident event delivered to notification channels
+type Notification struct {
+	Monitor    *models.Monitor
+	Result     *CheckResults // check that opened or resolved the incident, may be nil
+	Incident   *models.Incident
+	Severity   incident.Severity
+	Event      string
+	Attributes map[string]any
+	Resolved   bool
+	Reminder   int // number of the follow-up for an incident still open, 0 for the first notification
+	Time       time.Time
+}
+
+// Notifier delivers notifications to a single channel
+type Notifier interface {
+	Name() string
+	Notify(n *Notification) error
+}
+
+// NotifierFactory builds a notifier from its channel configuration
+type NotifierFactory func(cfg configuration.ChannelConfig) (Notifier, error)
+
+var notifierFactories = map[string]NotifierFactory{}
+
+// RegisterNotifier registers a notifier factory for a channel type
+func RegisterNotifier(channelType string, factory NotifierFactory) {
+	notifierFactories[channelType] = factory
+}
+
+// NewNotifier builds the notifier for a channel configuration
+func NewNotifier(cfg configuration.ChannelConfig) (Notifier, error) {
+	factory, ok := notifierFactories[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported notification channel type: %q", cfg.Type)
+	}
+	return factory(cfg)
+}
+
+// Dispatcher fans notifications out to the channels selected by the
+// notification routes without blocking the caller.
+type Dispatcher struct {
+	notifiers []Notifier
+	byName    map[string]Notifier
+	routes    []configuration.RouteConfig
+	wg        sync.WaitGroup
+}
+
+// NewDispatcher builds a dispatcher for the configured channels and routes,
+// skipping the channels that cannot be built.
+func NewDispatcher(cfg configuration.NotificationsConfig) *Dispatcher {
+	d := &Dispatcher{
+		byName: make(map[string]Notifier, len(cfg.Channels)),
+		routes: cfg.Routes,
+	}
+
+	for _, channel := range cfg.Channels {
+		notifier, err := NewNotifier(channel)
+		if err != nil {
+			log.Warn().Err(err).Str("channel", channel.Name).Msg("ignoring notification channel")
+			continue
+		}
+		d.notifiers = append(d.notifiers, notifier)
+		d.byName[notifier.Name()] = notifier
+	}
+
+	return d
+}
+
+// Dispatch sends n to the channels selected by the routes in the background
+func (d *Dispatcher) Dispatch(n *Notification) {
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+
+	notifiers := d.route(n)
+	if len(notifiers) == 0 && len(d.notifiers) > 0 {
+		log.Debug().Msgf("no notification route matched for %s", n.URL())
+	}
+
+	for _, notifier := range notifiers {
+		d.wg.Add(1)
+		go func(notifier Notifier) {
+			defer d.wg.Done()
+
+			if err := notifier.Notify(n); err != nil {
+				log.Error().Err(err).Str("channel", notifier.Name()).Msgf("failed to send notification for %s", n.URL())
+				return
+			}
+			log.Debug().Str("channel", notifier.Name()).Msgf("notification sent for %s", n.URL())
+		}(notifier)
+	}
+}
+
+// Wait blocks until every pending notification has been sent
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// URL returns the monitored url of the notification
+func (n *Notification) URL() string {
+	if n.Monitor != nil {
+		return n.Monitor.URL
+	}
+	if n.Incident != nil {
+		return n.Incident.Monitor.URL
+	}
+	return ""
+}
+
+// Title returns a short headline such as "[DOWN] https://example.com"
+func (n *Notification) Title() string {
+	return fmt.Sprintf("[%s] %s", n.Label(), n.URL())
+}
+
+// Label names the kind of notification: DOWN, DEGRADED, PARTIAL, FLAPPING, CERTIFICATE or RECOVERED
+func (n *Notification) Label() string {
+	switch {
+	case n.Resolved:
+		return "RECOVERED"
+	case n.Event == incident.EventWebsiteCertificateExpired, n.Event == incident.EventWebsiteCertificateInvalid:
+		return "CERTIFICATE"
+	case n.Event == incident.EventWebsiteDegraded:
+		return "DEGRADED"
+	case n.Event == incident.EventWebsitePartialOutage:
+		return "PARTIAL"
+	case n.Event == incident.EventWebsiteFlapping:
+		return "FLAPPING"
+	default:
+		return "DOWN"
+	}
+}
+
+// Text returns the notification body
+func (n *Notification) Text() string {
+	if n.Incident == nil {
+		return ""
+	}
+	if n.Resolved {
+		return fmt.Sprintf("Incident %s resolved after %s", n.Incident.Type, n.Time.Sub(n.Incident.CreatedAt).Round(time.Second))
+	}
+	if n.Reminder > 0 {
+		return fmt.Sprintf("Still open after %s: %s", n.Time.Sub(n.Incident.CreatedAt).Round(time.Second), n.Incident.Description)
+	}
+	return n.Incident.Description
+}
+
+// MonitorID returns the id of the monitor the notification is about
+func (n *Notification) MonitorID() string {
+	if n.Monitor != nil && n.Monitor.ID != "" {
+		return n.Monitor.ID
+	}
+	if n.Incident != nil {
+		return n.Incident.MonitorID
+	}
+	return ""
+}
+
+// DedupKey identifies the alert of a monitor and incident type in external
+// alerting systems, so that a trigger and its resolve refer to the same alert.
+func (n *Notification) DedupKey() string {
+	incidentType := ""
+	if n.Incident != nil {
+		incidentType = string(n.Incident.Type)
+	}
+	return fmt.Sprintf("uptime-go/%s/%s", n.MonitorID(), incidentType)
+}
+
+// Details returns the notification fields as a flat map
+func (n *Notification) Details() map[string]string {
+	details := make(map[string]string)
+	for _, field := range n.Fields() {
+		details[field.Name] = field.Value
+	}
+	if n.URL() != "" {
+		details["url"] = n.URL()
+	}
+	return details
+}
+
+// Color returns the hex color used by chat integrations
+func (n *Notification) Color() string {
+	switch {
+	case n.Resolved:
+		return "2EB67D"
+	case n.Severity == incident.CRITICAL || n.Severity == incident.HIGH:
+		return "E01E5A"
+	case n.Severity == incident.MEDIUM:
+		return "ECB22E"
+	default:
+		return "36C5F0"
+	}
+}
+
+// notificationField is a name/value pair displayed by chat integrations
+type notificationField struct {
+	Name  string
+	Value string
+}
+
+// Fields returns the incident details, the attributes sorted by name
+func (n *Notification) Fields() []notificationField {
+	var fields []notificationField
+
+	if n.Incident != nil {
+		fields = append(fields, notificationField{Name: "type", Value: string(n.Incident.Type)})
+	}
+	if n.Severity != "" && !n.Resolved {
+		fields = append(fields, notificationField{Name: "severity", Value: string(n.Severity)})
+	}
+
+	keys := make([]string, 0, len(n.Attributes))
+	for key := range n.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := formatAttribute(n.Attributes[key])
+		if value == "" {
+			continue
+		}
+		fields = append(fields, notificationField{Name: key, Value: value})
+	}
+
+	return fields
+}
+
+func formatAttribute(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ", ")
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// postJSON sends payload to an incoming webhook and fails on non-2xx responses
+func postJSON(url string, payload any) error {
+	return sendJSON(http.MethodPost, url, nil, payload)
+}
+
+// sendJSON sends payload with the given headers and fails on non-2xx responses
+func sendJSON(method string, url string, headers map[string]string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification payload: %w", err)
+	}
+
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request for %s: %w", url, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "GenbuUptimePlugin/"+version.VERSION)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("notification rejected with status code %d. Body: %s", response.StatusCode, string(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package net
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/incident"
+	"uptime-go/internal/models"
+)
+
+// capturedRequest is a request received by captureServer
+type capturedRequest struct {
+	method  string
+	uri     string
+	header  http.Header
+	body    []byte
+	payload map[string]any // decoded body
+}
+
+// captureServer returns a webhook endpoint answering with status and a
+// function returning the next request it receives
+func captureServer(t *testing.T, status int) (string, func() capturedRequest) {
+	t.Helper()
+
+	requests := make(chan capturedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected json content type, got %q", r.Header.Get("Content-Type"))
+		}
+
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid payload %s: %v", body, err)
+		}
+		requests <- capturedRequest{
+			method:  r.Method,
+			uri:     r.URL.RequestURI(),
+			header:  r.Header.Clone(),
+			body:    body,
+			payload: payload,
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, func() capturedRequest {
+		select {
+		case request := <-requests:
+			return request
+		case <-time.After(2 * time.Second):
+			t.Fatal("no request received")
+			return capturedRequest{}
+		}
+	}
+}
+
+func testNotification(resolved bool) *Notification {
+	monitor := &models.Monitor{URL: "https://example.com"}
+	return &Notification{
+		Monitor: monitor,
+		Incident: &models.Incident{
+			Type:        incident.Timeout,
+			Description: "Request timed out after 5s: https://example.com",
+			CreatedAt:   time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		Severity:   incident.HIGH,
+		Event:      incident.EventWebsiteDown,
+		Attributes: map[string]any{"status_code": 0, "error_message": "timeout"},
+		Resolved:   resolved,
+		Time:       time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC),
+	}
+}
+
+func newTestNotifier(t *testing.T, channelType, url string) Notifier {
+	t.Helper()
+
+	notifier, err := NewNotifier(configuration.ChannelConfig{Name: "test", Type: channelType, WebhookURL: url})
+	if err != nil {
+		t.Fatalf("failed to create %s notifier: %v", channelType, err)
+	}
+	return notifier
+}
+
+func TestSlackNotifier(t *testing.T) {
+	url, next := captureServer(t, http.StatusOK)
+	notifier := newTestNotifier(t, ChannelSlack, url)
+
+	if err := notifier.Notify(testNotification(false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := next().payload
+	if got["text"] != "[DOWN] https://example.com" {
+		t.Errorf("unexpected text: %v", got["text"])
+	}
+
+	attachment := got["attachments"].([]any)[0].(map[string]any)
+	if attachment["color"] != "#E01E5A" {
+		t.Errorf("unexpected color: %v", attachment["color"])
+	}
+	if attachment["text"] != "Request timed out after 5s: https://example.com" {
+		t.Errorf("unexpected attachment text: %v", attachment["text"])
+	}
+
+	fields := attachment["fields"].([]any)
+	first := fields[0].(map[string]any)
+	if first["title"] != "type" || first["value"] != "timeout" {
+		t.Errorf("unexpected first field: %v", first)
+	}
+}
+
+func TestDiscordNotifier(t *testing.T) {
+	url, next := captureServer(t, http.StatusNoContent)
+	notifier := newTestNotifier(t, ChannelDiscord, url)
+
+	if err := notifier.Notify(testNotification(true)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	embed := next().payload["embeds"].([]any)[0].(map[string]any)
+	if embed["title"] != "[RECOVERED] https://example.com" {
+		t.Errorf("unexpected title: %v", embed["title"])
+	}
+	if embed["description"] != "Incident timeout resolved after 5m0s" {
+		t.Errorf("unexpected description: %v", embed["description"])
+	}
+	if embed["color"] != float64(0x2EB67D) {
+		t.Errorf("unexpected color: %v", embed["color"])
+	}
+	if embed["timestamp"] != "2025-01-01T10:05:00Z" {
+		t.Errorf("unexpected timestamp: %v", embed["timestamp"])
+	}
+}
+
+func TestTeamsNotifier(t *testing.T) {
+	url, next := captureServer(t, http.StatusOK)
+	notifier := newTestNotifier(t, ChannelTeams, url)
+
+	n := testNotification(false)
+	n.Event = incident.EventWebsiteCertificateExpired
+	n.Severity = incident.INFO
+	if err := notifier.Notify(n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := next().payload
+	if got["@type"] != "MessageCard" || got["themeColor"] != "36C5F0" {
+		t.Errorf("unexpected card: %v", got)
+	}
+	if got["title"] != "[CERTIFICATE] https://example.com" {
+		t.Errorf("unexpected title: %v", got["title"])
+	}
+
+	facts := got["sections"].([]any)[0].(map[string]any)["facts"].([]any)
+	var names []string
+	for _, fact := range facts {
+		names = append(names, fact.(map[string]any)["name"].(string))
+	}
+	if strings.Join(names, ",") != "type,severity,error_message,status_code" {
+		t.Errorf("unexpected facts: %v", names)
+	}
+}
+
+func TestNotifierRejectedStatus(t *testing.T) {
+	url, _ := captureServer(t, http.StatusBadRequest)
+	notifier := newTestNotifier(t, ChannelSlack, url)
+
+	err := notifier.Notify(testNotification(false))
+	if err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("expected rejected status error, got %v", err)
+	}
+}
+
+func TestNewDispatcherSkipsInvalidChannels(t *testing.T) {
+	dispatcher := NewDispatcher(configuration.NotificationsConfig{
+		Channels: []configuration.ChannelConfig{
+			{Name: "unknown", Type: "pigeon", WebhookURL: "http://example.com"},
+			{Name: "missing-url", Type: ChannelSlack},
+			{Name: "ok", Type: ChannelDiscord, WebhookURL: "http://example.com"},
+		},
+	})
+
+	if len(dispatcher.notifiers) != 1 || dispatcher.notifiers[0].Name() != "ok" {
+		t.Errorf("expected only the valid channel, got %d notifiers", len(dispatcher.notifiers))
+	}
+}
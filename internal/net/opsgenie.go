@@ -0,0 +1,101 @@
+package net
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/helper"
+	"uptime-go/internal/incident"
+)
+
+// ChannelOpsgenie sends alerts to the Opsgenie Alert API
+const ChannelOpsgenie = "opsgenie"
+
+const opsgenieAPIURL = "https://api.opsgenie.com"
+
+// opsgenieNotifier creates an alert when an incident opens and closes it when
+// the incident is solved. The alert alias is the notification dedup key.
+type opsgenieNotifier struct {
+	name   string
+	url    string
+	apiKey string
+}
+
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Priority    string            `json:"priority"`
+	Source      string            `json:"source"`
+	Entity      string            `json:"entity,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+type opsgenieClose struct {
+	Source string `json:"source"`
+	Note   string `json:"note,omitempty"`
+}
+
+func init() {
+	RegisterNotifier(ChannelOpsgenie, func(cfg configuration.ChannelConfig) (Notifier, error) {
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("opsgenie channel %q requires api_key", cfg.Name)
+		}
+		return &opsgenieNotifier{
+			name:   cfg.Name,
+			url:    strings.TrimSuffix(helper.OrDefault(cfg.APIURL, opsgenieAPIURL), "/"),
+			apiKey: cfg.APIKey,
+		}, nil
+	})
+}
+
+func (o *opsgenieNotifier) Name() string {
+	return o.name
+}
+
+func (o *opsgenieNotifier) Notify(n *Notification) error {
+	headers := map[string]string{"Authorization": "GenieKey " + o.apiKey}
+
+	if n.Resolved {
+		closeURL := fmt.Sprintf("%s/v2/alerts/%s/close?identifierType=alias", o.url, url.PathEscape(n.DedupKey()))
+		return sendJSON(http.MethodPost, closeURL, headers, opsgenieClose{
+			Source: "uptime-go",
+			Note:   n.Text(),
+		})
+	}
+
+	alert := opsgenieAlert{
+		Message:     helper.Truncate(n.Title(), 127),
+		Alias:       n.DedupKey(),
+		Description: helper.Truncate(n.Text(), 15000-3),
+		Priority:    opsgeniePriority(n.Severity),
+		Source:      "uptime-go",
+		Entity:      n.URL(),
+		Tags:        []string{"uptime", "monitoring"},
+		Details:     n.Details(),
+	}
+	if n.Incident != nil {
+		alert.Tags = append(alert.Tags, string(n.Incident.Type))
+	}
+
+	return sendJSON(http.MethodPost, o.url+"/v2/alerts", headers, alert)
+}
+
+// opsgeniePriority maps incident severities to priorities P1 (CRITICAL) to P5 (INFO)
+func opsgeniePriority(severity incident.Severity) string {
+	switch severity {
+	case incident.CRITICAL:
+		return "P1"
+	case incident.HIGH:
+		return "P2"
+	case incident.MEDIUM:
+		return "P3"
+	case incident.LOW:
+		return "P4"
+	default:
+		return "P5"
+	}
+}
@@ -0,0 +1,69 @@
+package net
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/incident"
+)
+
+func TestOpsgenieNotifier(t *testing.T) {
+	url, next := captureServer(t, http.StatusAccepted)
+
+	notifier, err := NewNotifier(configuration.ChannelConfig{
+		Name:   "genie",
+		Type:   ChannelOpsgenie,
+		APIKey: "k3y",
+		APIURL: url + "/",
+	})
+	if err != nil {
+		t.Fatalf("failed to create notifier: %v", err)
+	}
+
+	n := testNotification(false)
+	n.Monitor.ID = "monitor-1"
+	if err := notifier.Notify(n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	create := next()
+	if create.uri != "/v2/alerts" || create.header.Get("Authorization") != "GenieKey k3y" {
+		t.Errorf("unexpected create request %s (%s)", create.uri, create.header.Get("Authorization"))
+	}
+
+	var alert opsgenieAlert
+	if err := json.Unmarshal(create.body, &alert); err != nil {
+		t.Fatalf("invalid payload %s: %v", create.body, err)
+	}
+	if alert.Alias != "uptime-go/monitor-1/timeout" || alert.Priority != "P2" || alert.Message != "[DOWN] https://example.com" {
+		t.Errorf("unexpected alert: %s", create.body)
+	}
+
+	resolved := testNotification(true)
+	resolved.Monitor.ID = "monitor-1"
+	if err := notifier.Notify(resolved); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	closeRequest := next()
+	if closeRequest.uri != "/v2/alerts/uptime-go%2Fmonitor-1%2Ftimeout/close?identifierType=alias" {
+		t.Errorf("unexpected close request %s", closeRequest.uri)
+	}
+}
+
+func TestOpsgeniePriority(t *testing.T) {
+	tests := map[incident.Severity]string{
+		incident.CRITICAL: "P1",
+		incident.HIGH:     "P2",
+		incident.MEDIUM:   "P3",
+		incident.LOW:      "P4",
+		incident.INFO:     "P5",
+	}
+
+	for severity, expected := range tests {
+		if got := opsgeniePriority(severity); got != expected {
+			t.Errorf("opsgeniePriority(%s) = %s, want %s", severity, got, expected)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/incident"
+	"uptime-go/internal/models"
+	"uptime-go/internal/net/database"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	outboxBatchSize    = 100
+	outboxPollInterval = 30 * time.Second
+	outboxMinBackoff   = 5 * time.Second
+	outboxMaxBackoff   = 15 * time.Minute
+)
+
+// Outbox persists notifications for the master in the database and delivers
+// them in the background, retrying with exponential backoff until the master
+// accepts them. Messages of one incident are delivered in order, so a status
+// update is never sent before the incident has been created remotely.
+type Outbox struct {
+	db         *database.Database
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mutex    sync.Mutex // serializes Flush
+	wake     chan struct{}
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func NewOutbox(db *database.Database) *Outbox {
+	return &Outbox{
+		db:         db,
+		minBackoff: outboxMinBackoff,
+		maxBackoff: outboxMaxBackoff,
+		wake:       make(chan struct{}, 1),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// masterConfigured reports whether incidents can be sent to a master at all
+func masterConfigured() bool {
+	return configuration.Config.Agent.MasterHost != "" && configuration.Config.Agent.Auth.Token != ""
+}
+
+// EnqueueIncident queues the creation of inc on the master. The incident
+// must already be stored, its IncidentID is filled in once delivered.
+func (o *Outbox) EnqueueIncident(inc *models.Incident, severity incident.Severity, event string, attributes map[string]any) error {
+	if !masterConfigured() {
+		return nil
+	}
+
+	attr := make(map[string]any, len(attributes))
+	maps.Copy(attr, attributes)
+
+	return o.enqueue(&models.OutboxMessage{
+		IncidentID: inc.ID,
+		Action:     models.OutboxCreateIncident,
+		Severity:   severity,
+		Event:      event,
+		Attributes: attr,
+	})
+}
+
+// EnqueueStatus queues a status update of inc on the master
+func (o *Outbox) EnqueueStatus(inc *models.Incident, status incident.Status) error {
+	if !masterConfigured() {
+		return nil
+	}
+
+	return o.enqueue(&models.OutboxMessage{
+		IncidentID: inc.ID,
+		Action:     models.OutboxUpdateStatus,
+		Status:     status,
+	})
+}
+
+func (o *Outbox) enqueue(message *models.OutboxMessage) error {
+	message.NextAttemptAt = time.Now()
+	if err := o.db.EnqueueOutbox(message); err != nil {
+		log.Error().Err(err).Str("incident", message.IncidentID).Msg("failed to enqueue notification")
+		return err
+	}
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Start launches the background sender
+func (o *Outbox) Start() {
+	o.wg.Add(1)
+	go o.loop()
+}
+
+// Stop stops the background sender, pending messages stay in the database
+func (o *Outbox) Stop() {
+	o.stopOnce.Do(func() {
+		close(o.stopChan)
+	})
+	o.wg.Wait()
+}
+
+func (o *Outbox) loop() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		wait := o.Flush()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ticker.C:
+		case <-o.wake:
+		case <-o.stopChan:
+			timer.Stop()
+			return
+		}
+		timer.Stop()
+	}
+}
+
+// Flush delivers every due message and returns the delay until the next
+// message becomes due.
+func (o *Outbox) Flush() time.Duration {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	wait := outboxPollInterval
+
+	messages, err := o.db.GetOutboxMessages(outboxBatchSize)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to read outbox")
+		return wait
+	}
+
+	now := time.Now()
+	blocked := make(map[string]bool)
+
+	for i := range messages {
+		message := &messages[i]
+
+		// Keep per-incident ordering: later messages wait for earlier ones
+		if blocked[message.IncidentID] {
+			continue
+		}
+
+		if message.NextAttemptAt.After(now) {
+			blocked[message.IncidentID] = true
+			wait = min(wait, message.NextAttemptAt.Sub(now))
+			continue
+		}
+
+		if err := o.deliver(message); err != nil {
+			blocked[message.IncidentID] = true
+
+			message.Attempts++
+			message.LastError = err.Error()
+			message.NextAttemptAt = now.Add(o.backoff(message.Attempts))
+			wait = min(wait, message.NextAttemptAt.Sub(now))
+
+			if err := o.db.Upsert(message); err != nil {
+				log.Error().Err(err).Uint64("message", message.ID).Msg("failed to update outbox message")
+			}
+
+			log.Warn().
+				Err(err).
+				Str("incident", message.IncidentID).
+				Str("action", message.Action).
+				Int("attempts", message.Attempts).
+				Time("next_attempt", message.NextAttemptAt).
+				Msg("notification delivery failed, will retry")
+			continue
+		}
+
+		if err := o.db.DeleteOutboxMessage(message.ID); err != nil {
+			log.Error().Err(err).Msg("failed to remove delivered outbox message")
+		}
+	}
+
+	return wait
+}
+
+// errDiscard marks messages that can never be delivered
+var errDiscard = errors.New("notification discarded")
+
+func (o *Outbox) deliver(message *models.OutboxMessage) error {
+	err := o.send(message)
+	if errors.Is(err, errDiscard) {
+		log.Error().
+			Err(err).
+			Str("incident", message.IncidentID).
+			Str("action", message.Action).
+			Int("attempts", message.Attempts+1).
+			Msg("dropping notification")
+		return nil
+	}
+	return err
+}
+
+func (o *Outbox) send(message *models.OutboxMessage) error {
+	inc, err := o.db.GetIncident(message.IncidentID)
+	if err != nil {
+		return err
+	}
+	if inc == nil {
+		return fmt.Errorf("%w: incident no longer exists", errDiscard)
+	}
+
+	switch message.Action {
+	case models.OutboxCreateIncident:
+		// A create whose backfill failed only retries the backfill
+		if message.RemoteID == 0 {
+			id, err := NotifyIncident(inc, message.Severity, message.Event, message.Attributes)
+			if err != nil {
+				return err
+			}
+			message.RemoteID = id
+		}
+		// Backfill the remote id so later status updates can reach the master
+		if err := o.db.SetRemoteIncidentID(inc.ID, message.RemoteID); err != nil {
+			return fmt.Errorf("failed to store remote incident id: %w", err)
+		}
+		return nil
+	case models.OutboxUpdateStatus:
+		if inc.IncidentID == 0 {
+			return fmt.Errorf("%w: incident was never created on the master", errDiscard)
+		}
+		return UpdateIncidentStatus(inc, message.Status)
+	default:
+		return fmt.Errorf("%w: unknown action %q", errDiscard, message.Action)
+	}
+}
+
+// backoff returns the retry delay after the given number of failed attempts
+func (o *Outbox) backoff(attempts int) time.Duration {
+	delay := o.minBackoff
+	for i := 1; i < attempts && delay < o.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, o.maxBackoff)
+}
@@ -0,0 +1,288 @@
+package net
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/incident"
+	"uptime-go/internal/models"
+	"uptime-go/internal/net/database"
+
+	"gorm.io/gorm"
+)
+
+// fakeMaster records the requests it receives, fails while down is set and
+// answers with status when set
+type fakeMaster struct {
+	mutex    sync.Mutex
+	down     bool
+	status   int
+	requests []string
+}
+
+func (f *fakeMaster) setDown(down bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.down = down
+}
+
+func (f *fakeMaster) setStatus(status int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.status = status
+}
+
+func (f *fakeMaster) paths() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+func (f *fakeMaster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	f.requests = append(f.requests, r.URL.Path)
+
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+
+	if strings.HasSuffix(r.URL.Path, "/incidents/add") {
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"incident_id": 42}})
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"message": "ok"})
+}
+
+func setupOutbox(t *testing.T) (*Outbox, *database.Database, *fakeMaster) {
+	t.Helper()
+
+	master := &fakeMaster{}
+	server := httptest.NewServer(master)
+	t.Cleanup(server.Close)
+
+	previous := configuration.Config.Agent
+	configuration.Config.Agent.MasterHost = server.URL
+	configuration.Config.Agent.Auth.Token = "secret"
+	t.Cleanup(func() { configuration.Config.Agent = previous })
+
+	// Avoid looking up the public ip address
+	once.Do(func() { ipAddress = "192.0.2.1" })
+
+	db, err := database.InitializeTestDatabase()
+	if err != nil {
+		t.Fatalf("failed to initialize database: %v", err)
+	}
+
+	outbox := NewOutbox(db)
+	outbox.minBackoff = 0
+	return outbox, db, master
+}
+
+func createIncident(t *testing.T, db *database.Database) *models.Incident {
+	t.Helper()
+
+	monitor := &models.Monitor{ID: "monitor-1", URL: "https://example.com"}
+	inc := &models.Incident{
+		ID:          "incident-1",
+		MonitorID:   monitor.ID,
+		Type:        incident.Timeout,
+		Description: "Request timed out",
+		Monitor:     *monitor,
+	}
+	if err := db.DB.Create(inc).Error; err != nil {
+		t.Fatalf("failed to create incident: %v", err)
+	}
+	return inc
+}
+
+func pendingMessages(t *testing.T, db *database.Database) []models.OutboxMessage {
+	t.Helper()
+
+	messages, err := db.GetOutboxMessages(100)
+	if err != nil {
+		t.Fatalf("failed to read outbox: %v", err)
+	}
+	return messages
+}
+
+func TestOutboxRetriesUntilMasterIsReachable(t *testing.T) {
+	outbox, db, master := setupOutbox(t)
+	inc := createIncident(t, db)
+
+	master.setDown(true)
+	_ = outbox.EnqueueIncident(inc, incident.HIGH, incident.EventWebsiteDown, map[string]any{"status_code": 0})
+	_ = outbox.EnqueueStatus(inc, incident.Resolved)
+
+	outbox.Flush()
+
+	messages := pendingMessages(t, db)
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 pending messages, got %d", len(messages))
+	}
+	if messages[0].Attempts != 1 || messages[0].LastError == "" {
+		t.Errorf("expected failed create attempt to be recorded, got %+v", messages[0])
+	}
+	if messages[1].Attempts != 0 {
+		t.Errorf("status update must wait for the create, got %d attempts", messages[1].Attempts)
+	}
+
+	master.setDown(false)
+	outbox.Flush()
+
+	if messages := pendingMessages(t, db); len(messages) != 0 {
+		t.Fatalf("expected outbox to be empty, got %d messages", len(messages))
+	}
+
+	paths := master.paths()
+	if len(paths) != 2 || !strings.HasSuffix(paths[0], "/incidents/add") || !strings.HasSuffix(paths[1], "/incidents/42/update-status") {
+		t.Errorf("expected create before status update, got %v", paths)
+	}
+
+	stored, _ := db.GetIncident(inc.ID)
+	if stored.IncidentID != 42 {
+		t.Errorf("expected remote incident id to be backfilled, got %d", stored.IncidentID)
+	}
+}
+
+func TestOutboxKeepsRemoteIDOnUpsert(t *testing.T) {
+	outbox, db, _ := setupOutbox(t)
+	inc := createIncident(t, db)
+
+	_ = outbox.EnqueueIncident(inc, incident.HIGH, incident.EventWebsiteDown, nil)
+	outbox.Flush()
+
+	// A stale copy saved by the monitor must not clear the remote id
+	now := time.Now()
+	inc.SolvedAt = &now
+	if err := db.Upsert(inc); err != nil {
+		t.Fatalf("failed to upsert incident: %v", err)
+	}
+
+	stored, _ := db.GetIncident(inc.ID)
+	if stored.IncidentID != 42 {
+		t.Errorf("expected remote incident id to be kept, got %d", stored.IncidentID)
+	}
+	if stored.SolvedAt == nil {
+		t.Error("expected solved_at to be updated")
+	}
+}
+
+func TestOutboxDropsRejectedMessages(t *testing.T) {
+	tests := []struct {
+		status  int
+		dropped bool
+	}{
+		{status: http.StatusUnprocessableEntity, dropped: true},
+		{status: http.StatusBadRequest, dropped: true},
+		{status: http.StatusTooManyRequests, dropped: false},
+		{status: http.StatusUnauthorized, dropped: false},
+		{status: http.StatusBadGateway, dropped: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			outbox, db, master := setupOutbox(t)
+			inc := createIncident(t, db)
+
+			master.setStatus(tt.status)
+			_ = outbox.EnqueueIncident(inc, incident.HIGH, incident.EventWebsiteDown, nil)
+			outbox.Flush()
+
+			if pending := len(pendingMessages(t, db)); (pending == 0) != tt.dropped {
+				t.Errorf("status %d: expected dropped %v, got %d pending messages", tt.status, tt.dropped, pending)
+			}
+		})
+	}
+}
+
+func TestOutboxRetriesRemoteIDBackfill(t *testing.T) {
+	outbox, db, master := setupOutbox(t)
+	inc := createIncident(t, db)
+
+	var failing atomic.Bool
+	failing.Store(true)
+	err := db.DB.Callback().Raw().Before("gorm:raw").Register("test:fail_backfill", func(tx *gorm.DB) {
+		if failing.Load() {
+			_ = tx.AddError(errors.New("database is locked"))
+		}
+	})
+	if err != nil {
+		t.Fatalf("failed to register callback: %v", err)
+	}
+	t.Cleanup(func() { _ = db.DB.Callback().Raw().Remove("test:fail_backfill") })
+
+	_ = outbox.EnqueueIncident(inc, incident.HIGH, incident.EventWebsiteDown, nil)
+	_ = outbox.EnqueueStatus(inc, incident.Resolved)
+	outbox.Flush()
+
+	messages := pendingMessages(t, db)
+	if len(messages) != 2 || messages[0].RemoteID != 42 {
+		t.Fatalf("expected the create to be kept with its remote id, got %+v", messages)
+	}
+
+	failing.Store(false)
+	outbox.Flush()
+
+	if messages := pendingMessages(t, db); len(messages) != 0 {
+		t.Fatalf("expected outbox to be empty, got %d messages", len(messages))
+	}
+
+	paths := master.paths()
+	if len(paths) != 2 || !strings.HasSuffix(paths[1], "/incidents/42/update-status") {
+		t.Errorf("expected a single create followed by the status update, got %v", paths)
+	}
+}
+
+func TestOutboxDropsStatusOfUnknownIncident(t *testing.T) {
+	outbox, db, master := setupOutbox(t)
+	inc := createIncident(t, db)
+
+	_ = outbox.EnqueueStatus(inc, incident.Resolved)
+	outbox.Flush()
+
+	if messages := pendingMessages(t, db); len(messages) != 0 {
+		t.Fatalf("expected status update without remote incident to be dropped, got %d messages", len(messages))
+	}
+	if paths := master.paths(); len(paths) != 0 {
+		t.Errorf("expected no request to the master, got %v", paths)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	outbox := &Outbox{minBackoff: 5 * time.Second, maxBackoff: time.Minute}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{3, 20 * time.Second},
+		{4, 40 * time.Second},
+		{5, time.Minute},
+		{50, time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := outbox.backoff(tt.attempts); got != tt.expected {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package net
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/helper"
+	"uptime-go/internal/version"
+)
+
+// ChannelWebhook sends notifications to a user defined HTTP endpoint
+const ChannelWebhook = "webhook"
+
+// DefaultSignatureHeader carries the HMAC-SHA256 signature of the request body
+const DefaultSignatureHeader = "X-Uptime-Signature"
+
+// webhookNotifier renders the body template and sends it to an arbitrary
+// endpoint. When a secret is configured the body is signed with
+// HMAC-SHA256 and the signature is sent as "sha256=<hex>".
+type webhookNotifier struct {
+	name            string
+	url             string
+	method          string
+	headers         map[string]string
+	body            *template.Template
+	secret          []byte
+	signatureHeader string
+}
+
+// webhookPayload is the body sent when no body_template is configured
+type webhookPayload struct {
+	Event      string          `json:"event"`
+	Status     string          `json:"status"`
+	Title      string          `json:"title"`
+	Message    string          `json:"message"`
+	Severity   string          `json:"severity,omitempty"`
+	Incident   webhookIncident `json:"incident"`
+	Monitor    webhookMonitor  `json:"monitor"`
+	Result     *webhookResult  `json:"result,omitempty"`
+	Attributes map[string]any  `json:"attributes,omitempty"`
+	Time       time.Time       `json:"time"`
+}
+
+type webhookIncident struct {
+	ID          string     `json:"id"`
+	Type        string     `json:"type"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	SolvedAt    *time.Time `json:"solved_at,omitempty"`
+}
+
+type webhookMonitor struct {
+	ID   string `json:"id"`
+	URL  string `json:"url"`
+	Type string `json:"type"`
+}
+
+type webhookResult struct {
+	IsUp         bool       `json:"is_up"`
+	StatusCode   int        `json:"status_code"`
+	ResponseTime int64      `json:"response_time"` // in milliseconds
+	ErrorMessage string     `json:"error_message,omitempty"`
+	SSLExpiredAt *time.Time `json:"ssl_expired_date,omitempty"`
+	CheckedAt    time.Time  `json:"checked_at"`
+}
+
+// webhookTemplateFuncs are available in body templates
+var webhookTemplateFuncs = template.FuncMap{
+	"json": func(value any) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	"ms": func(d time.Duration) int64 {
+		return d.Milliseconds()
+	},
+}
+
+func init() {
+	RegisterNotifier(ChannelWebhook, newWebhookNotifier)
+}
+
+func newWebhookNotifier(cfg configuration.ChannelConfig) (Notifier, error) {
+	if cfg.WebhookURL == "" {
+		return nil, fmt.Errorf("webhook channel %q requires webhook_url", cfg.Name)
+	}
+
+	method := strings.ToUpper(strings.TrimSpace(cfg.Method))
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	var body *template.Template
+	if cfg.BodyTemplate != "" {
+		var err error
+		body, err = template.New(cfg.Name).Funcs(webhookTemplateFuncs).Option("missingkey=zero").Parse(cfg.BodyTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("webhook channel %q has invalid body_template: %w", cfg.Name, err)
+		}
+	}
+
+	return &webhookNotifier{
+		name:            cfg.Name,
+		url:             cfg.WebhookURL,
+		method:          method,
+		headers:         cfg.Headers,
+		body:            body,
+		secret:          []byte(cfg.Secret),
+		signatureHeader: helper.OrDefault(cfg.SignatureHeader, DefaultSignatureHeader),
+	}, nil
+}
+
+func (w *webhookNotifier) Name() string {
+	return w.name
+}
+
+func (w *webhookNotifier) Notify(n *Notification) error {
+	body, err := w.render(n)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(w.method, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request for %s: %w", w.url, err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "GenbuUptimePlugin/"+version.VERSION)
+	for key, value := range w.headers {
+		request.Header.Set(key, value)
+	}
+
+	if len(w.secret) > 0 {
+		request.Header.Set(w.signatureHeader, "sha256="+SignPayload(w.secret, body))
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("webhook rejected with status code %d. Body: %s", response.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// render returns the request body, either from the body template or the
+// default JSON payload.
+func (w *webhookNotifier) render(n *Notification) ([]byte, error) {
+	if w.body != nil {
+		var buf bytes.Buffer
+		if err := w.body.Execute(&buf, n); err != nil {
+			return nil, fmt.Errorf("failed to render webhook body: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	payload := webhookPayload{
+		Event:      n.Event,
+		Status:     "open",
+		Title:      n.Title(),
+		Message:    n.Text(),
+		Severity:   string(n.Severity),
+		Attributes: n.Attributes,
+		Time:       n.Time,
+	}
+	if n.Resolved {
+		payload.Status = "resolved"
+	}
+	if n.Incident != nil {
+		payload.Incident = webhookIncident{
+			ID:          n.Incident.ID,
+			Type:        string(n.Incident.Type),
+			Description: n.Incident.Description,
+			CreatedAt:   n.Incident.CreatedAt,
+			SolvedAt:    n.Incident.SolvedAt,
+		}
+	}
+	if n.Monitor != nil {
+		payload.Monitor = webhookMonitor{ID: n.Monitor.ID, URL: n.Monitor.URL, Type: n.Monitor.Type}
+	}
+	if n.Result != nil {
+		payload.Result = &webhookResult{
+			IsUp:         n.Result.IsUp,
+			StatusCode:   n.Result.StatusCode,
+			ResponseTime: n.Result.ResponseTime.Milliseconds(),
+			ErrorMessage: n.Result.ErrorMessage,
+			SSLExpiredAt: n.Result.SSLExpiredDate,
+			CheckedAt:    n.Result.LastCheck,
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	return body, nil
+}
+
+// SignPayload returns the hex encoded HMAC-SHA256 of body. Receivers verify
+// a request by computing the same value with the shared secret.
+func SignPayload(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
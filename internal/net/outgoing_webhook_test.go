@@ -0,0 +1,122 @@
+package net
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+	"uptime-go/internal/configuration"
+)
+
+func TestWebhookNotifierTemplate(t *testing.T) {
+	url, next := captureServer(t, http.StatusAccepted)
+
+	notifier, err := NewNotifier(configuration.ChannelConfig{
+		Name:       "tickets",
+		Type:       ChannelWebhook,
+		WebhookURL: url,
+		Method:     "put",
+		Headers:    map[string]string{"X-Api-Key": "abc"},
+		BodyTemplate: `{"summary": {{json .Title}}, "type": "{{.Incident.Type}}", ` +
+			`"response_ms": {{ms .Result.ResponseTime}}, "status": {{.Result.StatusCode}}, ` +
+			`"error": {{json (index .Attributes "error_message")}}}`,
+		Secret: "s3cret",
+	})
+	if err != nil {
+		t.Fatalf("failed to create notifier: %v", err)
+	}
+
+	n := testNotification(false)
+	n.Result = &CheckResults{StatusCode: 503, ResponseTime: 1500 * time.Millisecond}
+	if err := notifier.Notify(n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	request := next()
+	if request.method != http.MethodPut {
+		t.Errorf("expected PUT, got %s", request.method)
+	}
+	if request.header.Get("X-Api-Key") != "abc" {
+		t.Errorf("expected custom header, got %v", request.header)
+	}
+
+	expected := `{"summary": "[DOWN] https://example.com", "type": "timeout", "response_ms": 1500, "status": 503, "error": "timeout"}`
+	if string(request.body) != expected {
+		t.Errorf("unexpected body:\n%s\nwant:\n%s", request.body, expected)
+	}
+
+	signature := request.header.Get(DefaultSignatureHeader)
+	if signature != "sha256="+SignPayload([]byte("s3cret"), request.body) {
+		t.Errorf("invalid signature %q", signature)
+	}
+}
+
+func TestWebhookNotifierDefaultPayload(t *testing.T) {
+	url, next := captureServer(t, http.StatusAccepted)
+
+	notifier, err := NewNotifier(configuration.ChannelConfig{
+		Name:       "tickets",
+		Type:       ChannelWebhook,
+		WebhookURL: url,
+	})
+	if err != nil {
+		t.Fatalf("failed to create notifier: %v", err)
+	}
+
+	if err := notifier.Notify(testNotification(true)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	request := next()
+	if request.method != http.MethodPost {
+		t.Errorf("expected POST, got %s", request.method)
+	}
+	if request.header.Get(DefaultSignatureHeader) != "" {
+		t.Error("expected no signature without a secret")
+	}
+
+	var payload struct {
+		Status   string `json:"status"`
+		Title    string `json:"title"`
+		Incident struct {
+			Type string `json:"type"`
+		} `json:"incident"`
+		Monitor struct {
+			URL string `json:"url"`
+		} `json:"monitor"`
+		Result *struct{} `json:"result"`
+	}
+	if err := json.Unmarshal(request.body, &payload); err != nil {
+		t.Fatalf("invalid payload %s: %v", request.body, err)
+	}
+
+	if payload.Status != "resolved" || payload.Title != "[RECOVERED] https://example.com" {
+		t.Errorf("unexpected payload: %s", request.body)
+	}
+	if payload.Incident.Type != "timeout" || payload.Monitor.URL != "https://example.com" {
+		t.Errorf("unexpected incident or monitor: %s", request.body)
+	}
+	if payload.Result != nil {
+		t.Errorf("expected no result, got %s", request.body)
+	}
+}
+
+func TestWebhookNotifierInvalidTemplate(t *testing.T) {
+	_, err := NewNotifier(configuration.ChannelConfig{
+		Name:         "broken",
+		Type:         ChannelWebhook,
+		WebhookURL:   "http://example.com",
+		BodyTemplate: "{{.Title",
+	})
+	if err == nil {
+		t.Error("expected invalid template error")
+	}
+}
+
+func TestSignPayload(t *testing.T) {
+	// Reference value from: echo -n '{"a":1}' | openssl dgst -sha256 -hmac key
+	expected := "88a67f24bbcdaed0e6c997404bb79a743baf44c6bab2f4c27328e3009d22e342"
+	if got := SignPayload([]byte("key"), []byte(`{"a":1}`)); got != expected {
+		t.Errorf("SignPayload() = %s, want %s", got, expected)
+	}
+}
@@ -0,0 +1,97 @@
+package net
+
+import (
+	"fmt"
+	"time"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/helper"
+	"uptime-go/internal/incident"
+)
+
+// ChannelPagerDuty sends events to the PagerDuty Events API v2
+const ChannelPagerDuty = "pagerduty"
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// pagerDutyNotifier triggers an alert when an incident opens and resolves it
+// when the incident is solved, both identified by the notification dedup key.
+type pagerDutyNotifier struct {
+	name       string
+	url        string
+	routingKey string
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+func init() {
+	RegisterNotifier(ChannelPagerDuty, func(cfg configuration.ChannelConfig) (Notifier, error) {
+		if cfg.RoutingKey == "" {
+			return nil, fmt.Errorf("pagerduty channel %q requires routing_key", cfg.Name)
+		}
+		return &pagerDutyNotifier{
+			name:       cfg.Name,
+			url:        helper.OrDefault(cfg.APIURL, pagerDutyEventsURL),
+			routingKey: cfg.RoutingKey,
+		}, nil
+	})
+}
+
+func (p *pagerDutyNotifier) Name() string {
+	return p.name
+}
+
+func (p *pagerDutyNotifier) Notify(n *Notification) error {
+	event := pagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: "trigger",
+		DedupKey:    n.DedupKey(),
+	}
+
+	if n.Resolved {
+		event.EventAction = "resolve"
+		return postJSON(p.url, event)
+	}
+
+	event.Payload = &pagerDutyPayload{
+		Summary:       helper.Truncate(n.Title()+": "+n.Text(), 1021),
+		Source:        n.URL(),
+		Severity:      pagerDutySeverity(n.Severity),
+		Timestamp:     n.Time.UTC().Format(time.RFC3339),
+		Component:     "uptime-go",
+		CustomDetails: n.Details(),
+	}
+	if n.Incident != nil {
+		event.Payload.Class = string(n.Incident.Type)
+	}
+
+	return postJSON(p.url, event)
+}
+
+// pagerDutySeverity maps incident severities to critical, error, warning or info
+func pagerDutySeverity(severity incident.Severity) string {
+	switch severity {
+	case incident.CRITICAL:
+		return "critical"
+	case incident.HIGH:
+		return "error"
+	case incident.MEDIUM:
+		return "warning"
+	default:
+		return "info"
+	}
+}
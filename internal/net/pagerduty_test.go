@@ -0,0 +1,82 @@
+package net
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/incident"
+)
+
+func TestPagerDutyNotifier(t *testing.T) {
+	url, next := captureServer(t, http.StatusAccepted)
+
+	notifier, err := NewNotifier(configuration.ChannelConfig{
+		Name:       "pager",
+		Type:       ChannelPagerDuty,
+		RoutingKey: "R0UT1NG",
+		APIURL:     url,
+	})
+	if err != nil {
+		t.Fatalf("failed to create notifier: %v", err)
+	}
+
+	n := testNotification(false)
+	n.Monitor.ID = "monitor-1"
+	if err := notifier.Notify(n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var trigger pagerDutyEvent
+	request := next()
+	if err := json.Unmarshal(request.body, &trigger); err != nil {
+		t.Fatalf("invalid payload %s: %v", request.body, err)
+	}
+
+	if trigger.RoutingKey != "R0UT1NG" || trigger.EventAction != "trigger" {
+		t.Errorf("unexpected event: %s", request.body)
+	}
+	if trigger.DedupKey != "uptime-go/monitor-1/timeout" {
+		t.Errorf("unexpected dedup key %q", trigger.DedupKey)
+	}
+	if trigger.Payload == nil || trigger.Payload.Severity != "error" || trigger.Payload.Source != "https://example.com" {
+		t.Errorf("unexpected payload: %s", request.body)
+	}
+	if trigger.Payload.CustomDetails["error_message"] != "timeout" {
+		t.Errorf("expected attributes in custom details, got %v", trigger.Payload.CustomDetails)
+	}
+
+	resolved := testNotification(true)
+	resolved.Monitor.ID = "monitor-1"
+	if err := notifier.Notify(resolved); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resolve pagerDutyEvent
+	request = next()
+	if err := json.Unmarshal(request.body, &resolve); err != nil {
+		t.Fatalf("invalid payload %s: %v", request.body, err)
+	}
+	if resolve.EventAction != "resolve" || resolve.DedupKey != trigger.DedupKey || resolve.Payload != nil {
+		t.Errorf("unexpected resolve event: %s", request.body)
+	}
+	if request.method != http.MethodPost {
+		t.Errorf("expected POST, got %s", request.method)
+	}
+}
+
+func TestPagerDutySeverity(t *testing.T) {
+	tests := map[incident.Severity]string{
+		incident.CRITICAL: "critical",
+		incident.HIGH:     "error",
+		incident.MEDIUM:   "warning",
+		incident.LOW:      "info",
+		incident.INFO:     "info",
+	}
+
+	for severity, expected := range tests {
+		if got := pagerDutySeverity(severity); got != expected {
+			t.Errorf("pagerDutySeverity(%s) = %s, want %s", severity, got, expected)
+		}
+	}
+}
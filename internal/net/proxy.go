@@ -0,0 +1,130 @@
+package net
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxyFunc returns the proxy selector of the check: the configured proxy,
+// the environment (HTTP_PROXY, HTTPS_PROXY and NO_PROXY) when enabled, or
+// nil to connect directly.
+func (nc *NetworkConfig) proxyFunc() (func(*url.URL) (*url.URL, error), error) {
+	if nc.Proxy != "" {
+		proxyURL, err := parseProxyURL(nc.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		return func(*url.URL) (*url.URL, error) { return proxyURL, nil }, nil
+	}
+
+	if nc.ProxyFromEnvironment {
+		return httpproxy.FromEnvironment().ProxyFunc(), nil
+	}
+
+	return nil, nil
+}
+
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil || proxyURL.Host == "" {
+		return nil, errors.New("invalid proxy url")
+	}
+
+	switch proxyURL.Scheme = strings.ToLower(proxyURL.Scheme); proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return proxyURL, nil
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", proxyURL.Scheme)
+	}
+}
+
+// proxyTrace follows the connection of the current request. The transport
+// dials the proxy with the custom DialContext, so its DNS and connect timings
+// are the ones of the proxy, and the tunnel is set up (CONNECT or SOCKS
+// handshake) before the target handshake starts or the connection is handed
+// over. Connections are not reused, every request dials again.
+type proxyTrace struct {
+	proxy      *url.URL  // proxy of the current request, nil when direct
+	dialed     time.Time // when the connection to the proxy was established
+	handshakes int       // TLS handshakes started on the connection
+}
+
+// start selects the proxy of a request and resets the trace
+func (pt *proxyTrace) start(proxyFor func(*url.URL) (*url.URL, error), target *url.URL) (*url.URL, error) {
+	*pt = proxyTrace{}
+	if proxyFor == nil {
+		return nil, nil
+	}
+
+	proxyURL, err := proxyFor(target)
+	if err != nil {
+		return nil, err
+	}
+	pt.proxy = proxyURL
+	return proxyURL, nil
+}
+
+// isProxyHandshake reports whether the running TLS handshake is the one
+// with an https proxy, which comes before the tunnel to the target
+func (pt *proxyTrace) isProxyHandshake() bool {
+	return pt.proxy != nil && pt.proxy.Scheme == "https" && pt.handshakes == 1
+}
+
+// tunnelEstablished records the tunnel setup time once per connection
+func (pt *proxyTrace) tunnelEstablished(result *CheckResults) {
+	if pt.proxy == nil || pt.dialed.IsZero() {
+		return
+	}
+	result.ProxyTime = time.Since(pt.dialed)
+	pt.dialed = time.Time{}
+}
+
+func (pt *proxyTrace) clientTrace(result *CheckResults) *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		TLSHandshakeStart: func() {
+			pt.handshakes++
+			if !pt.isProxyHandshake() {
+				pt.tunnelEstablished(result)
+			}
+		},
+		GotConn: func(httptrace.GotConnInfo) {
+			pt.tunnelEstablished(result)
+		},
+	}
+}
+
+// verifyProxy verifies the certificate of an https proxy against the roots
+// of the check. The proxy credentials are sent on this connection, so a
+// proxy with credentials is verified even when certificate monitoring is off.
+func (nc *NetworkConfig) verifyProxy(state tls.ConnectionState, roots *x509.CertPool, proxy *url.URL) error {
+	if nc.SkipSSL && proxy.User == nil {
+		return nil
+	}
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("proxy presented no certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		DNSName:       proxy.Hostname(),
+	})
+	if err != nil {
+		return fmt.Errorf("proxy certificate is not trusted: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package net
+
+import (
+	"slices"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/helper"
+	"uptime-go/internal/incident"
+)
+
+// route returns the notifiers selected for n. Without routes every notifier
+// is selected, otherwise the routes are evaluated in order and the first
+// match stops the evaluation unless it sets continue.
+func (d *Dispatcher) route(n *Notification) []Notifier {
+	if len(d.routes) == 0 {
+		return d.notifiers
+	}
+
+	var selected []Notifier
+	seen := make(map[string]bool)
+
+	for _, route := range d.routes {
+		if !matchRoute(route, n) {
+			continue
+		}
+
+		for _, name := range route.Channels {
+			notifier, ok := d.byName[name]
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			selected = append(selected, notifier)
+		}
+
+		if !route.Continue {
+			break
+		}
+	}
+
+	return selected
+}
+
+// matchRoute reports whether n satisfies every condition set on route
+func matchRoute(route configuration.RouteConfig, n *Notification) bool {
+	if len(route.URLs) > 0 {
+		url := n.URL()
+		if !slices.ContainsFunc(route.URLs, func(pattern string) bool { return helper.MatchGlob(pattern, url) }) {
+			return false
+		}
+	}
+
+	if len(route.Tags) > 0 {
+		if n.Monitor == nil || !slices.ContainsFunc(n.Monitor.Tags, func(tag string) bool { return slices.Contains(route.Tags, tag) }) {
+			return false
+		}
+	}
+
+	if len(route.IncidentTypes) > 0 {
+		if n.Incident == nil || !slices.Contains(route.IncidentTypes, string(n.Incident.Type)) {
+			return false
+		}
+	}
+
+	if route.MinSeverity != "" && n.Severity.Level() < incident.Severity(route.MinSeverity).Level() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,108 @@
+package net
+
+import (
+	"slices"
+	"testing"
+	"uptime-go/internal/configuration"
+	"uptime-go/internal/incident"
+	"uptime-go/internal/models"
+)
+
+type namedNotifier string
+
+func (n namedNotifier) Name() string               { return string(n) }
+func (n namedNotifier) Notify(*Notification) error { return nil }
+
+func routingDispatcher(routes []configuration.RouteConfig) *Dispatcher {
+	d := &Dispatcher{byName: map[string]Notifier{}, routes: routes}
+	for _, name := range []string{"email", "pager", "chat"} {
+		d.notifiers = append(d.notifiers, namedNotifier(name))
+		d.byName[name] = namedNotifier(name)
+	}
+	return d
+}
+
+func routedNames(d *Dispatcher, n *Notification) []string {
+	var names []string
+	for _, notifier := range d.route(n) {
+		names = append(names, notifier.Name())
+	}
+	return names
+}
+
+func TestDispatcherWithoutRoutes(t *testing.T) {
+	d := routingDispatcher(nil)
+
+	names := routedNames(d, testNotification(false))
+	if !slices.Equal(names, []string{"email", "pager", "chat"}) {
+		t.Errorf("expected every channel without routes, got %v", names)
+	}
+}
+
+func TestDispatcherRoutes(t *testing.T) {
+	d := routingDispatcher([]configuration.RouteConfig{
+		{Name: "certificates", IncidentTypes: []string{string(incident.SSLExpired)}, Channels: []string{"email"}},
+		{Name: "staging", URLs: []string{"https://*.staging.example.com/*"}, Channels: []string{"chat"}},
+		{Name: "page", MinSeverity: string(incident.HIGH), Tags: []string{"prod"}, Channels: []string{"pager"}, Continue: true},
+		{Name: "everything", Channels: []string{"chat", "pager"}},
+	})
+
+	notification := func(url string, tags []string, incidentType incident.Type, severity incident.Severity) *Notification {
+		return &Notification{
+			Monitor:  &models.Monitor{URL: url, Tags: tags},
+			Incident: &models.Incident{Type: incidentType, Severity: severity},
+			Severity: severity,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		n        *Notification
+		expected []string
+	}{
+		{
+			name:     "certificate almost expired goes to email only",
+			n:        notification("https://example.com", []string{"prod"}, incident.SSLExpired, incident.INFO),
+			expected: []string{"email"},
+		},
+		{
+			name:     "staging glob",
+			n:        notification("https://api.staging.example.com/health", []string{"prod"}, incident.Timeout, incident.HIGH),
+			expected: []string{"chat"},
+		},
+		{
+			name:     "down high pages and continues",
+			n:        notification("https://example.com", []string{"prod", "api"}, incident.Timeout, incident.HIGH),
+			expected: []string{"pager", "chat"},
+		},
+		{
+			name:     "below min severity",
+			n:        notification("https://example.com", []string{"prod"}, incident.SlowResponse, incident.MEDIUM),
+			expected: []string{"chat", "pager"},
+		},
+		{
+			name:     "tag mismatch",
+			n:        notification("https://example.com", []string{"dev"}, incident.Timeout, incident.CRITICAL),
+			expected: []string{"chat", "pager"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			names := routedNames(d, test.n)
+			if !slices.Equal(names, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, names)
+			}
+		})
+	}
+}
+
+func TestDispatcherNoMatchingRoute(t *testing.T) {
+	d := routingDispatcher([]configuration.RouteConfig{
+		{URLs: []string{"https://other.example.com"}, Channels: []string{"email"}},
+	})
+
+	if names := routedNames(d, testNotification(false)); len(names) != 0 {
+		t.Errorf("expected no channel, got %v", names)
+	}
+}
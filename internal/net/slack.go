@@ -0,0 +1,64 @@
+package net
+
+import (
+	"fmt"
+	"uptime-go/internal/configuration"
+)
+
+// slackNotifier posts notifications to a Slack incoming webhook
+type slackNotifier struct {
+	name       string
+	webhookURL string
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Title  string       `json:"title"`
+	Text   string       `json:"text"`
+	Fields []slackField `json:"fields,omitempty"`
+	Footer string       `json:"footer"`
+	TS     int64        `json:"ts"`
+}
+
+type slackPayload struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+func init() {
+	RegisterNotifier(ChannelSlack, func(cfg configuration.ChannelConfig) (Notifier, error) {
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("slack channel %q requires webhook_url", cfg.Name)
+		}
+		return &slackNotifier{name: cfg.Name, webhookURL: cfg.WebhookURL}, nil
+	})
+}
+
+func (s *slackNotifier) Name() string {
+	return s.name
+}
+
+func (s *slackNotifier) Notify(n *Notification) error {
+	var fields []slackField
+	for _, field := range n.Fields() {
+		fields = append(fields, slackField{Title: field.Name, Value: field.Value, Short: len(field.Value) <= 40})
+	}
+
+	return postJSON(s.webhookURL, slackPayload{
+		Text: n.Title(),
+		Attachments: []slackAttachment{{
+			Color:  "#" + n.Color(),
+			Title:  n.Title(),
+			Text:   n.Text(),
+			Fields: fields,
+			Footer: "uptime-go",
+			TS:     n.Time.Unix(),
+		}},
+	})
+}
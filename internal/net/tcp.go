@@ -0,0 +1,135 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+	"uptime-go/internal/helper"
+)
+
+// maxBannerSize limits how much of a TCP response is buffered while
+// looking for the expected banner.
+const maxBannerSize = 4096
+
+// ErrUnexpectedResponse is returned when a TCP service answers with data
+// that does not match the configured expectation.
+var ErrUnexpectedResponse = errors.New("unexpected response")
+
+// tcpChecker opens a TCP connection to host:port and optionally exchanges data
+type tcpChecker struct{}
+
+func (tcpChecker) Check(nc *NetworkConfig) (*CheckResults, error) {
+	return nc.CheckTCP()
+}
+
+func init() {
+	RegisterChecker(CheckerTCP, tcpChecker{})
+}
+
+// CheckTCP dials the monitor address and reports it UP once the connection
+// is accepted. When TCPPayload is set it is written after connecting, and
+// when TCPExpect is set the response must match it as a regular expression.
+func (nc *NetworkConfig) CheckTCP() (*CheckResults, error) {
+	result := &CheckResults{
+		URL:       nc.URL,
+		LastCheck: time.Now(),
+		IsUp:      false,
+	}
+
+	dnsTimeout := durationOrDefault(nc.DNSTimeout, 5*time.Second)
+	dialTimeout := durationOrDefault(nc.DialTimeout, 10*time.Second)
+	totalTimeout := durationOrDefault(nc.Timeout, 30*time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), totalTimeout)
+	defer cancel()
+
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+	}
+
+	start := time.Now()
+	conn, err := nc.dialContext(ctx, dialer, dnsTimeout, "tcp", TCPAddress(nc.URL), result)
+	if err != nil {
+		result.ResponseTime = time.Since(start)
+		result.ErrorMessage = nc.categorizeError(err, dnsTimeout, dialTimeout)
+		return result, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if nc.TCPPayload != "" {
+		if _, err := conn.Write([]byte(nc.TCPPayload)); err != nil {
+			result.ResponseTime = time.Since(start)
+			result.ErrorMessage = nc.categorizeError(err, dnsTimeout, dialTimeout)
+			return result, err
+		}
+	}
+
+	if nc.TCPExpect != nil {
+		response, err := readUntilMatch(conn, nc.TCPExpect)
+		result.FirstByteTime = time.Since(start) - result.DNSTime - result.ConnectTime
+		if err != nil {
+			result.ResponseTime = time.Since(start)
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				result.ErrorMessage = fmt.Sprintf("Timed out waiting for expected response from %s", nc.URL)
+				return result, err
+			}
+
+			err = fmt.Errorf("%w from %s: %q does not match %q", ErrUnexpectedResponse, nc.URL, helper.Truncate(string(response), 128), nc.TCPExpect.String())
+			result.ErrorMessage = err.Error()
+			return result, err
+		}
+	}
+
+	result.ResponseTime = time.Since(start)
+	result.IsUp = true
+
+	return result, nil
+}
+
+// readUntilMatch reads from conn until the buffered data matches expect,
+// the peer closes the connection or maxBannerSize bytes have been read.
+func readUntilMatch(conn net.Conn, expect *regexp.Regexp) ([]byte, error) {
+	var buf bytes.Buffer
+	chunk := make([]byte, 512)
+
+	for buf.Len() < maxBannerSize {
+		n, err := conn.Read(chunk)
+		buf.Write(chunk[:n])
+
+		if expect.Match(buf.Bytes()) {
+			return buf.Bytes(), nil
+		}
+
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return buf.Bytes(), err
+			}
+			return buf.Bytes(), ErrUnexpectedResponse
+		}
+	}
+
+	return buf.Bytes(), ErrUnexpectedResponse
+}
+
+// TCPAddress strips the optional tcp:// scheme from a monitor URL, leaving host:port
+func TCPAddress(rawURL string) string {
+	return strings.TrimPrefix(rawURL, "tcp://")
+}
+
+func durationOrDefault(d, defaultValue time.Duration) time.Duration {
+	if d <= 0 {
+		return defaultValue
+	}
+	return d
+}
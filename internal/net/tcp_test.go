@@ -0,0 +1,121 @@
+package net
+
+import (
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func startTCPServer(t *testing.T, handle func(conn net.Conn)) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+
+	return "tcp://" + listener.Addr().String()
+}
+
+func TestCheckTCP(t *testing.T) {
+	t.Run("port accepting connections", func(t *testing.T) {
+		url := startTCPServer(t, func(conn net.Conn) {})
+
+		nc := NetworkConfig{URL: url, Timeout: 2 * time.Second}
+		results, err := nc.CheckTCP()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !results.IsUp {
+			t.Fatalf("expected tcp check to be up, got %+v", results)
+		}
+	})
+
+	t.Run("banner matches", func(t *testing.T) {
+		url := startTCPServer(t, func(conn net.Conn) {
+			_, _ = conn.Write([]byte("SSH-2.0-OpenSSH_9.6\r\n"))
+		})
+
+		nc := NetworkConfig{URL: url, Timeout: 2 * time.Second, TCPExpect: regexp.MustCompile(`^SSH-2\.0-`)}
+		results, err := nc.CheckTCP()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !results.IsUp {
+			t.Fatalf("expected tcp check to be up, got %+v", results)
+		}
+	})
+
+	t.Run("payload response matches", func(t *testing.T) {
+		url := startTCPServer(t, func(conn net.Conn) {
+			buf := make([]byte, 16)
+			n, _ := conn.Read(buf)
+			if string(buf[:n]) == "PING\r\n" {
+				_, _ = conn.Write([]byte("+PONG\r\n"))
+			}
+		})
+
+		nc := NetworkConfig{URL: url, Timeout: 2 * time.Second, TCPPayload: "PING\r\n", TCPExpect: regexp.MustCompile("PONG")}
+		results, err := nc.CheckTCP()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !results.IsUp {
+			t.Fatalf("expected tcp check to be up, got %+v", results)
+		}
+	})
+
+	t.Run("banner mismatch", func(t *testing.T) {
+		url := startTCPServer(t, func(conn net.Conn) {
+			_, _ = conn.Write([]byte("220 smtp.example.com ESMTP\r\n"))
+		})
+
+		nc := NetworkConfig{URL: url, Timeout: 2 * time.Second, TCPExpect: regexp.MustCompile("^SSH-")}
+		results, err := nc.CheckTCP()
+		if !errors.Is(err, ErrUnexpectedResponse) {
+			t.Fatalf("expected ErrUnexpectedResponse, got %v", err)
+		}
+		if results.IsUp {
+			t.Errorf("expected tcp check to be down")
+		}
+		if !strings.Contains(results.ErrorMessage, "220 smtp.example.com") {
+			t.Errorf("expected error message to contain the received banner, got %q", results.ErrorMessage)
+		}
+	})
+
+	t.Run("connection refused", func(t *testing.T) {
+		listener, err := net.Listen("tcp4", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("failed to listen: %v", err)
+		}
+		addr := listener.Addr().String()
+		listener.Close()
+
+		nc := NetworkConfig{URL: "tcp://" + addr, Timeout: 2 * time.Second}
+		results, err := nc.CheckTCP()
+		if !errors.Is(err, syscall.ECONNREFUSED) {
+			t.Fatalf("expected connection refused, got %v", err)
+		}
+		if results.IsUp {
+			t.Errorf("expected tcp check to be down")
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package net
+
+import (
+	"fmt"
+	"uptime-go/internal/configuration"
+)
+
+// teamsNotifier posts notifications to a Microsoft Teams incoming webhook
+// using the MessageCard format.
+type teamsNotifier struct {
+	name       string
+	webhookURL string
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type teamsSection struct {
+	ActivityTitle    string      `json:"activityTitle"`
+	ActivitySubtitle string      `json:"activitySubtitle,omitempty"`
+	Facts            []teamsFact `json:"facts,omitempty"`
+	Markdown         bool        `json:"markdown"`
+}
+
+type teamsPayload struct {
+	Type       string         `json:"@type"`
+	Context    string         `json:"@context"`
+	ThemeColor string         `json:"themeColor"`
+	Summary    string         `json:"summary"`
+	Title      string         `json:"title"`
+	Text       string         `json:"text"`
+	Sections   []teamsSection `json:"sections,omitempty"`
+}
+
+func init() {
+	RegisterNotifier(ChannelTeams, func(cfg configuration.ChannelConfig) (Notifier, error) {
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("teams channel %q requires webhook_url", cfg.Name)
+		}
+		return &teamsNotifier{name: cfg.Name, webhookURL: cfg.WebhookURL}, nil
+	})
+}
+
+func (t *teamsNotifier) Name() string {
+	return t.name
+}
+
+func (t *teamsNotifier) Notify(n *Notification) error {
+	var facts []teamsFact
+	for _, field := range n.Fields() {
+		facts = append(facts, teamsFact{Name: field.Name, Value: field.Value})
+	}
+
+	return postJSON(t.webhookURL, teamsPayload{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		ThemeColor: n.Color(),
+		Summary:    n.Title(),
+		Title:      n.Title(),
+		Text:       n.Text(),
+		Sections: []teamsSection{{
+			ActivityTitle:    n.URL(),
+			ActivitySubtitle: n.Time.Format("2006-01-02 15:04:05 MST"),
+			Facts:            facts,
+			Markdown:         true,
+		}},
+	})
+}
@@ -0,0 +1,143 @@
+package net
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+	"uptime-go/internal/models"
+)
+
+// Minimum key sizes, smaller keys are reported as weak
+const (
+	minRSAKeySize   = 2048
+	minECDSAKeySize = 256
+)
+
+// inspectTLS describes the connection and the presented certificate chain.
+// With verify set, the chain is verified against roots (nil for the system
+// pool) and the leaf against host. Verification never fails the handshake,
+// the problems are reported in the returned info.
+func inspectTLS(state tls.ConnectionState, host string, verify bool, roots *x509.CertPool, now time.Time) *models.CertificateInfo {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	leaf := state.PeerCertificates[0]
+	keyType, keySize := publicKeyInfo(leaf.PublicKey)
+
+	info := &models.CertificateInfo{
+		Subject:            leaf.Subject.String(),
+		Issuer:             leaf.Issuer.String(),
+		DNSNames:           leaf.DNSNames,
+		SerialNumber:       fmt.Sprintf("%X", leaf.SerialNumber),
+		KeyType:            keyType,
+		KeySize:            keySize,
+		SignatureAlgorithm: leaf.SignatureAlgorithm.String(),
+		NotBefore:          leaf.NotBefore,
+		NotAfter:           leaf.NotAfter,
+		TLSVersion:         tls.VersionName(state.Version),
+		CipherSuite:        tls.CipherSuiteName(state.CipherSuite),
+	}
+	for _, ip := range leaf.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	for _, cert := range state.PeerCertificates {
+		info.Chain = append(info.Chain, models.ChainCertificate{
+			Subject:  cert.Subject.String(),
+			Issuer:   cert.Issuer.String(),
+			NotAfter: cert.NotAfter,
+		})
+	}
+
+	if !verify {
+		return info
+	}
+
+	info.Verified = true
+	info.SelfSigned = isSelfSigned(leaf)
+	info.WeakKey = isWeakKey(keyType, keySize)
+
+	// Certificates without a matching name for ip targets are common, the
+	// hostname is only verified for names
+	info.HostnameValid = net.ParseIP(host) != nil || leaf.VerifyHostname(host) == nil
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+		if now.After(cert.NotAfter) {
+			info.ExpiredIntermediates = append(info.ExpiredIntermediates, cert.Subject.String())
+		}
+	}
+
+	err := verifyChain(leaf, intermediates, roots, now)
+	if err != nil {
+		// Expired certificates are reported on their own, check whether the
+		// chain is trusted at a time every presented certificate was valid
+		var invalid x509.CertificateInvalidError
+		if errors.As(err, &invalid) && invalid.Reason == x509.Expired {
+			err = verifyChain(leaf, intermediates, roots, latestNotBefore(state.PeerCertificates))
+		}
+	}
+
+	info.ChainTrusted = err == nil
+	if err != nil {
+		info.ChainError = err.Error()
+	}
+
+	return info
+}
+
+func verifyChain(leaf *x509.Certificate, intermediates, roots *x509.CertPool, at time.Time) error {
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+	})
+	return err
+}
+
+func latestNotBefore(certs []*x509.Certificate) time.Time {
+	var latest time.Time
+	for _, cert := range certs {
+		if cert.NotBefore.After(latest) {
+			latest = cert.NotBefore
+		}
+	}
+	return latest
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.Subject.String() == cert.Issuer.String() &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// publicKeyInfo returns the key algorithm and its size in bits
+func publicKeyInfo(key any) (string, int) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return "RSA", k.N.BitLen()
+	case *ecdsa.PublicKey:
+		return "ECDSA", k.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return "Ed25519", 256
+	default:
+		return "unknown", 0
+	}
+}
+
+func isWeakKey(keyType string, keySize int) bool {
+	switch keyType {
+	case "RSA":
+		return keySize < minRSAKeySize
+	case "ECDSA":
+		return keySize < minECDSAKeySize
+	default:
+		return false
+	}
+}
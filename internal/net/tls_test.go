@@ -0,0 +1,400 @@
+package net
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"uptime-go/internal/models"
+)
+
+var testSerial int64
+
+// issueCert signs template with parent, or self-signs it when parent is nil
+func issueCert(t *testing.T, template *x509.Certificate, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) *x509.Certificate {
+	t.Helper()
+
+	testSerial++
+	template.SerialNumber = big.NewInt(testSerial)
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour)
+	}
+	if template.NotAfter.IsZero() {
+		template.NotAfter = time.Now().Add(24 * time.Hour)
+	}
+	if template.IsCA {
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return cert
+}
+
+func ecKey(t *testing.T) crypto.Signer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+// testPKI is a root and an intermediate CA issuing leaf certificates
+type testPKI struct {
+	root, intermediate       *x509.Certificate
+	rootKey, intermediateKey crypto.Signer
+}
+
+func newTestPKI(t *testing.T, intermediateNotAfter time.Time) *testPKI {
+	t.Helper()
+
+	pki := &testPKI{rootKey: ecKey(t), intermediateKey: ecKey(t)}
+	pki.root = issueCert(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "Test Root"},
+		IsCA:      true,
+		NotBefore: time.Now().Add(-72 * time.Hour),
+	}, pki.rootKey, nil, nil)
+	pki.intermediate = issueCert(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "Test Intermediate"},
+		IsCA:      true,
+		NotBefore: time.Now().Add(-48 * time.Hour),
+		NotAfter:  intermediateNotAfter,
+	}, pki.intermediateKey, pki.root, pki.rootKey)
+
+	return pki
+}
+
+func (p *testPKI) roots() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(p.root)
+	return pool
+}
+
+func (p *testPKI) leaf(t *testing.T, key crypto.Signer, names ...string) *x509.Certificate {
+	t.Helper()
+
+	return issueCert(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: names[0]},
+		DNSNames:  names,
+		NotBefore: time.Now().Add(-2 * time.Hour),
+	}, key, p.intermediate, p.intermediateKey)
+}
+
+func TestInspectTLS(t *testing.T) {
+	pki := newTestPKI(t, time.Now().Add(24*time.Hour))
+	leaf := pki.leaf(t, ecKey(t), "example.com", "www.example.com")
+	state := tls.ConnectionState{
+		Version:          tls.VersionTLS13,
+		CipherSuite:      tls.TLS_AES_128_GCM_SHA256,
+		PeerCertificates: []*x509.Certificate{leaf, pki.intermediate},
+	}
+
+	info := inspectTLS(state, "www.example.com", true, pki.roots(), time.Now())
+	if info == nil {
+		t.Fatal("expected certificate info")
+	}
+
+	if info.Subject != "CN=example.com" || info.Issuer != "CN=Test Intermediate" {
+		t.Errorf("unexpected subject %q or issuer %q", info.Subject, info.Issuer)
+	}
+	if info.KeyType != "ECDSA" || info.KeySize != 256 || info.SignatureAlgorithm != "ECDSA-SHA256" {
+		t.Errorf("unexpected key %s %d signed with %s", info.KeyType, info.KeySize, info.SignatureAlgorithm)
+	}
+	if info.TLSVersion != "TLS 1.3" || info.CipherSuite != "TLS_AES_128_GCM_SHA256" {
+		t.Errorf("unexpected connection %s %s", info.TLSVersion, info.CipherSuite)
+	}
+	if len(info.Chain) != 2 || info.SerialNumber == "" || len(info.DNSNames) != 2 {
+		t.Errorf("unexpected chain, serial or names: %+v", info)
+	}
+	if !info.Verified || !info.ChainTrusted || !info.HostnameValid || info.SelfSigned || info.WeakKey || len(info.ExpiredIntermediates) > 0 {
+		t.Errorf("expected a valid certificate, got %+v", info)
+	}
+}
+
+func TestInspectTLSProblems(t *testing.T) {
+	valid := newTestPKI(t, time.Now().Add(24*time.Hour))
+	expired := newTestPKI(t, time.Now().Add(-time.Hour))
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	selfSignedKey := ecKey(t)
+	selfSigned := issueCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: "example.com"}, DNSNames: []string{"example.com"}}, selfSignedKey, nil, nil)
+
+	testCases := []struct {
+		name  string
+		chain []*x509.Certificate
+		host  string
+		roots *x509.CertPool
+		check func(t *testing.T, info *models.CertificateInfo)
+	}{
+		{
+			name:  "hostname mismatch",
+			chain: []*x509.Certificate{valid.leaf(t, ecKey(t), "example.com"), valid.intermediate},
+			host:  "other.example.org",
+			roots: valid.roots(),
+			check: func(t *testing.T, info *models.CertificateInfo) {
+				if info.HostnameValid || !info.ChainTrusted {
+					t.Errorf("expected only a hostname mismatch, got %+v", info)
+				}
+			},
+		},
+		{
+			name:  "ip target skips hostname",
+			chain: []*x509.Certificate{valid.leaf(t, ecKey(t), "example.com"), valid.intermediate},
+			host:  "192.0.2.1",
+			roots: valid.roots(),
+			check: func(t *testing.T, info *models.CertificateInfo) {
+				if !info.HostnameValid {
+					t.Errorf("expected hostname check to be skipped, got %+v", info)
+				}
+			},
+		},
+		{
+			name:  "unknown authority",
+			chain: []*x509.Certificate{valid.leaf(t, ecKey(t), "example.com"), valid.intermediate},
+			host:  "example.com",
+			roots: x509.NewCertPool(),
+			check: func(t *testing.T, info *models.CertificateInfo) {
+				if info.ChainTrusted || info.SelfSigned || info.ChainError == "" {
+					t.Errorf("expected an untrusted chain, got %+v", info)
+				}
+			},
+		},
+		{
+			name:  "self-signed",
+			chain: []*x509.Certificate{selfSigned},
+			host:  "example.com",
+			roots: valid.roots(),
+			check: func(t *testing.T, info *models.CertificateInfo) {
+				if info.ChainTrusted || !info.SelfSigned {
+					t.Errorf("expected an untrusted self-signed certificate, got %+v", info)
+				}
+			},
+		},
+		{
+			name:  "expired intermediate",
+			chain: []*x509.Certificate{expired.leaf(t, ecKey(t), "example.com"), expired.intermediate},
+			host:  "example.com",
+			roots: expired.roots(),
+			check: func(t *testing.T, info *models.CertificateInfo) {
+				if len(info.ExpiredIntermediates) != 1 || info.ExpiredIntermediates[0] != "CN=Test Intermediate" {
+					t.Errorf("expected the expired intermediate, got %v", info.ExpiredIntermediates)
+				}
+				if !info.ChainTrusted {
+					t.Errorf("expected the chain to be trusted aside from expiry, got %s", info.ChainError)
+				}
+			},
+		},
+		{
+			name:  "weak key",
+			chain: []*x509.Certificate{valid.leaf(t, weakKey, "example.com"), valid.intermediate},
+			host:  "example.com",
+			roots: valid.roots(),
+			check: func(t *testing.T, info *models.CertificateInfo) {
+				if !info.WeakKey || info.KeyType != "RSA" || info.KeySize != 1024 {
+					t.Errorf("expected a weak RSA key, got %s %d", info.KeyType, info.KeySize)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			info := inspectTLS(tls.ConnectionState{PeerCertificates: tc.chain}, tc.host, true, tc.roots, time.Now())
+			if info == nil || !info.Verified {
+				t.Fatalf("expected verified certificate info, got %+v", info)
+			}
+			tc.check(t, info)
+		})
+	}
+}
+
+func TestInspectTLSWithoutVerification(t *testing.T) {
+	cert := issueCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: "example.com"}}, ecKey(t), nil, nil)
+
+	info := inspectTLS(tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}, "other.com", false, nil, time.Now())
+	if info == nil || info.Verified || info.Subject != "CN=example.com" {
+		t.Errorf("expected unverified certificate info, got %+v", info)
+	}
+	if inspectTLS(tls.ConnectionState{}, "example.com", true, nil, time.Now()) != nil {
+		t.Error("expected no info without peer certificates")
+	}
+}
+
+func TestCheckWebsiteTLSInspection(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	nc := &NetworkConfig{URL: server.URL, Timeout: 5 * time.Second}
+	result, err := nc.CheckWebsite()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.IsUp || result.Certificate == nil || result.SSLExpiredDate == nil {
+		t.Fatalf("expected an up result with certificate info, got %+v", result)
+	}
+	if result.Certificate.ChainTrusted || result.Certificate.TLSVersion == "" {
+		t.Errorf("expected the httptest certificate to be untrusted, got %+v", result.Certificate)
+	}
+
+	// Nothing is sent to a host the certificate does not cover, the
+	// inspection is still recorded
+	requests.Store(0)
+	nc.URL = strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	nc.Headers = map[string]string{"X-Api-Key": "secret"}
+	result, err = nc.CheckWebsite()
+	if err == nil || result.IsUp || !strings.Contains(result.ErrorMessage, "certificate is not valid for localhost") {
+		t.Errorf("expected the handshake to fail, got %q", result.ErrorMessage)
+	}
+	if result.Certificate == nil || result.Certificate.HostnameValid || requests.Load() != 0 {
+		t.Errorf("expected the inspection without a request, got %d requests and %+v", requests.Load(), result.Certificate)
+	}
+
+	// Without certificate monitoring the certificate is not verified
+	nc.SkipSSL = true
+	result, err = nc.CheckWebsite()
+	if err != nil || !result.IsUp || requests.Load() != 1 {
+		t.Errorf("expected the check to pass without verification, got %q", result.ErrorMessage)
+	}
+}
+
+// writePEM writes the certificates, or the private key, to a file in dir
+func writePEM(t *testing.T, dir, name string, key crypto.Signer, certs ...*x509.Certificate) string {
+	t.Helper()
+
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	if key != nil {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("failed to marshal key: %v", err)
+		}
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})...)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestCheckWebsiteCustomCAAndClientCertificate(t *testing.T) {
+	pki := newTestPKI(t, time.Now().Add(24*time.Hour))
+	dir := t.TempDir()
+
+	serverKey := ecKey(t)
+	serverCert := pki.leaf(t, serverKey, "internal.example")
+
+	clientKey := ecKey(t)
+	clientCert := pki.leaf(t, clientKey, "uptime-go")
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "uptime-go" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.TLS.ServerName != "internal.example" {
+			w.WriteHeader(http.StatusMisdirectedRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.Raw, pki.intermediate.Raw}, PrivateKey: serverKey}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pki.roots(),
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	nc := &NetworkConfig{
+		URL:            server.URL,
+		Timeout:        5 * time.Second,
+		CAFile:         writePEM(t, dir, "ca.pem", nil, pki.root),
+		ClientCertFile: writePEM(t, dir, "client.pem", nil, clientCert, pki.intermediate),
+		ClientKeyFile:  writePEM(t, dir, "client-key.pem", clientKey),
+		ServerName:     "internal.example",
+		BearerToken:    "secret",
+	}
+
+	result, err := nc.CheckWebsite()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsUp || result.StatusCode != http.StatusOK {
+		t.Fatalf("expected the check to pass, got %d: %s", result.StatusCode, result.ErrorMessage)
+	}
+	if info := result.Certificate; !info.ChainTrusted || !info.HostnameValid {
+		t.Errorf("expected a trusted certificate for the server name, got %+v", info)
+	}
+
+	t.Run("without client certificate", func(t *testing.T) {
+		withoutCert := *nc
+		withoutCert.ClientCertFile, withoutCert.ClientKeyFile = "", ""
+
+		result, err := withoutCert.CheckWebsite()
+		if err == nil || result.IsUp {
+			t.Errorf("expected the server to reject the connection, got %d", result.StatusCode)
+		}
+	})
+
+	t.Run("without custom ca", func(t *testing.T) {
+		withoutCA := *nc
+		withoutCA.CAFile = ""
+
+		result, err := withoutCA.CheckWebsite()
+		if err == nil || !strings.Contains(result.ErrorMessage, "certificate is not trusted") {
+			t.Errorf("expected an untrusted certificate, got %q", result.ErrorMessage)
+		}
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		invalid := *nc
+		invalid.CAFile = filepath.Join(dir, "missing.pem")
+
+		result, err := invalid.CheckWebsite()
+		if err == nil || !strings.Contains(result.ErrorMessage, "ca_file") {
+			t.Errorf("expected a ca_file error, got %q", result.ErrorMessage)
+		}
+	})
+}
@@ -23,6 +23,20 @@ type incidentResponse struct {
 	} `json:"data"`
 }
 
+// statusError marks the error of a request the master rejected for good.
+// Client errors cannot succeed on retry, except timeouts, rate limiting and
+// authentication errors, which go away once the master or token is fixed.
+func statusError(statusCode int, err error) error {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusUnauthorized, http.StatusForbidden:
+		return err
+	}
+	if statusCode >= 400 && statusCode < 500 {
+		return fmt.Errorf("%w: %w", errDiscard, err)
+	}
+	return err
+}
+
 func sendRequest(method string, url string, payload any) (*http.Response, []byte, error) {
 	client := &http.Client{
 		Timeout: 10 * time.Second,
@@ -111,7 +125,7 @@ func NotifyIncident(incident *models.Incident, severity incident.Severity, event
 	if response.StatusCode != http.StatusCreated {
 		err := fmt.Errorf("failed to create incident, received status code %d. Body: %s", response.StatusCode, string(body))
 		log.Error().Err(err).Msg("webhook error")
-		return 0, err
+		return 0, statusError(response.StatusCode, err)
 	}
 
 	var result incidentResponse
@@ -144,7 +158,7 @@ func UpdateIncidentStatus(incident *models.Incident, status incident.Status) err
 	if response.StatusCode != http.StatusOK {
 		err := fmt.Errorf("failed to update incident status, received status code %d. Body: %s", response.StatusCode, string(body))
 		log.Error().Err(err).Msg("webhook error")
-		return err
+		return statusError(response.StatusCode, err)
 	}
 
 	var result incidentResponse